@@ -0,0 +1,1261 @@
+// Copyright (c) 2021-2022 Doc.ai its affiliates.
+//
+// Copyright (c) 2023-2024 Cisco and/or its affiliates.
+//
+// Copyright (c) 2024-2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"golang.org/x/sys/unix"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/clientinfo"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/ipfamily"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/memifrole"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/memifsize"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/none"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/pingprobe"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/policer"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/srv"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/vlan"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/vsock"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
+	vlanmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vlan"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
+	"github.com/networkservicemesh/sdk/pkg/tools/awarenessgroups"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsurl"
+)
+
+const (
+	minInterfaceMTU = 576
+	maxInterfaceMTU = 9216
+	// VPP policer committed rate is uint32 in kbps
+	maxBandwidthMbps = 4000000
+	// DSCP is the 6 upper bits of the IP traffic class
+	maxDSCP = 63
+	// requestTimeoutParam - NSURL query parameter overriding RequestTimeout for the network service
+	requestTimeoutParam = "requestTimeout"
+	// maxRetriesParam - NSURL query parameter overriding RetryMaxRetries for the network service
+	maxRetriesParam = "maxRetries"
+	// pingParam - NSURL query parameter enabling the ping probe of the connection to the network service, the value
+	// is the address to ping or pingPeer for the NSE address
+	pingParam = "ping"
+	// pingTimeoutParam - NSURL query parameter setting the timeout of the ping probe
+	pingTimeoutParam = "pingTimeout"
+	pingPeer         = "peer"
+	// defaultPingTimeout - timeout of the ping probe if pingTimeoutParam is not set
+	defaultPingTimeout = 10 * time.Second
+	// extraContextParamPrefix - prefix of the NSURL query parameters setting the extra context of the connection to
+	// the network service: extra-KEY=VALUE
+	extraContextParamPrefix = "extra-"
+	// captureParam - NSURL query parameter enabling the packet capture of the interface of the connection to the
+	// network service
+	captureParam = "capture"
+	// connectionIDParam - NSURL query parameter setting the ID of the connection to the network service instead of the
+	// one derived from Name
+	connectionIDParam = "connectionId"
+	// macParam - NSURL query parameter setting the MAC address of the interface of the connection to the network
+	// service, the generated one is used if it is not set
+	macParam = "mac"
+	// tenantParam - NSURL query parameter setting the tenant of the connection to the network service instead of
+	// Tenant
+	tenantParam = "tenant"
+	// nseParam - NSURL query parameter pinning the connection to the network service to the NSE with this name
+	nseParam = "nse"
+	// Modes of the client
+	modeStandalone = "standalone"
+	modeInit       = "init"
+	modeRun        = "run"
+	// idleChecksPerTimeout - number of the polls of the interface stats per IdleTimeout if InterfaceStatsInterval is
+	// not set
+	idleChecksPerTimeout = 4
+	// Resume policies of the connections requested at startup
+	resumePolicyAuto    = "auto"
+	resumePolicyRequire = "require"
+	resumePolicyNever   = "never"
+	// Actions on a change of the watched interfaces
+	watchActionRequest = "request"
+	watchActionExit    = "exit"
+	// connectToEnv - environment variable of ConnectTo, ConnectToSRV is ignored if it is set
+	connectToEnv = "NSM_CONNECT_TO"
+	// allowNoHugepagesEnv - environment variable of AllowNoHugepages
+	allowNoHugepagesEnv = "NSM_ALLOW_NO_HUGEPAGES"
+	// vppConfigPathEnv - environment variable of VppConfigPath
+	vppConfigPathEnv = "NSM_VPP_CONFIG_PATH"
+	// defaultNSMgrPort - port of the tcp NSMgr URL if it has none
+	defaultNSMgrPort = "5001"
+	// maxVlanID - VLAN ID is 12 bits
+	maxVlanID = 4095
+	// VPP supports the memif rings up to 2^14 entries
+	minMemifRingSize   = 8
+	maxMemifRingSize   = 16384
+	minMemifBufferSize = 128
+	maxMemifBufferSize = 32768
+	// defaultMemifRingSize, defaultMemifBufferSize - sizes VPP uses for the memif interfaces if they are not set
+	defaultMemifRingSize   = 1024
+	defaultMemifBufferSize = 2048
+)
+
+// nonLabelParams - NSURL query parameters configuring the client, they are not sent as the labels of the connection
+var nonLabelParams = []string{requestTimeoutParam, maxRetriesParam, pingParam, pingTimeoutParam, vlanmech.ID, nseParam, captureParam, connectionIDParam, macParam, tenantParam, policer.DSCPLabel}
+
+// clientMechanismParams - mechanism parameters set by the client from the other options or by its mechanism chain
+// elements, they can't be set with MechanismParameters
+var clientMechanismParams = map[string]string{
+	common.InterfaceNameKey: "InterfaceName or the NSURL path",
+	common.InodeURL:         "the mechanism client",
+	vlanmech.ID:             "the vlan-id NSURL parameter",
+	memifsize.RingSizeKey:   "MemifRingSize",
+	memifsize.BufferSizeKey: "MemifBufferSize",
+	memifrole.Key:           "MemifRole",
+}
+
+// Config - configuration for cmd-forwarder-vpp
+type Config struct {
+	Name                  string                  `default:"cmd-nsc-vpp" desc:"Name of Endpoint, ${VAR} and $VAR are substituted with the environment variables, e.g. ${POD_NAME}.${NAMESPACE}"`
+	ConfigFile            string                  `default:"" desc:"Path to a JSON or YAML file with the config, the keys are the environment variables without the NSM_ prefix, e.g. log_level, the environment variables take precedence" split_words:"true"`
+	DialTimeout           time.Duration           `default:"5s" desc:"timeout to dial NSMgr by the network service client" split_words:"true"`
+	MonitorDialTimeout    time.Duration           `default:"0s" desc:"timeout to dial NSMgr by the monitor client, DialTimeout is used if 0" split_words:"true"`
+	RequestTimeout        time.Duration           `default:"15s" desc:"timeout to request NSE, can be overridden per network service with the requestTimeout NSURL parameter" split_words:"true"`
+	ConnectTo             url.URL                 `default:"unix:///var/lib/networkservicemesh/nsm.io.sock" desc:"url to connect to: unix:///path, tcp://host:port or vsock://CID:PORT" split_words:"true"`
+	MaxTokenLifetime      time.Duration           `default:"10m" desc:"maximum lifetime of tokens" split_words:"true"`
+	NetworkServices       []url.URL               `default:"" desc:"A list of Network Service Requests: memif://, kernel://, vlan:// or none:// for the services with no dataplane interface" split_words:"true"`
+	NetworkServicesFile   string                  `default:"" desc:"Path to a file with a Network Service Request per line used instead of the NetworkServices list, reloaded on SIGHUP" split_words:"true"`
+	AwarenessGroups       awarenessgroups.Decoder `defailt:"" desc:"Awareness groups for mutually aware NSEs" split_words:"true"`
+	AwarenessGroupsFiles  []string                `default:"" desc:"A list of files with awareness groups in the AwarenessGroups format merged with AwarenessGroups in order, the groups sharing an NSURL are merged into one" split_words:"true"`
+	ExcludedPrefixesFile  string                  `default:"" desc:"Path to a file with excluded prefixes, the file is watched for changes" split_words:"true"`
+	ConnectionLabels      keyValues               `default:"" desc:"Labels in KEY=VALUE form added to each connection, NSURL labels take precedence on conflict" split_words:"true"`
+	LogLevel              string                  `default:"INFO" desc:"Log level" split_words:"true"`
+	LogFormat             string                  `default:"text" desc:"Log format: text or json" split_words:"true"`
+	OpenTelemetryEndpoint string                  `default:"otel-collector.observability.svc.cluster.local:4317" desc:"OpenTelemetry Collector Endpoint" split_words:"true"`
+	MetricsExportInterval time.Duration           `default:"10s" desc:"interval between mertics exports" split_words:"true"`
+
+	LivenessCheckEnabled  bool          `default:"true" desc:"Dataplane liveness check enabled/disabled" split_words:"true"`
+	LivenessCheckInterval time.Duration `default:"1200ms" desc:"Dataplane liveness check interval" split_words:"true"`
+	LivenessCheckTimeout  time.Duration `default:"1s" desc:"Dataplane liveness check timeout" split_words:"true"`
+
+	PprofEnabled  bool   `default:"false" desc:"is pprof enabled" split_words:"true"`
+	PprofListenOn string `default:"localhost:6060" desc:"pprof URL to ListenAndServe" split_words:"true"`
+
+	SignalLogLevel string `default:"TRACE" desc:"Log level set on SIGUSR1, SIGUSR2 restores LogLevel" split_words:"true"`
+	AdminListenOn  string `default:"" desc:"Address to serve the admin endpoints on, e.g. localhost:6061, disabled if empty" split_words:"true"`
+	AdminToken     string `default:"" desc:"Bearer token required by the /drain and /resume admin endpoints, they are disabled if empty" split_words:"true"`
+
+	TokenFile             string   `default:"" desc:"Path to a file with the token sent to NSMgr instead of the SPIFFE JWT, the file is watched for rotation" split_words:"true"`
+	AuthorizedSpiffeIDs   []string `default:"" desc:"A list of SPIFFE IDs allowed for NSMgr, any ID is allowed if empty" split_words:"true"`
+	FederatedTrustDomains []string `default:"" desc:"A list of federated trust domains allowed for NSMgr in addition to the one of the SVID, used if AuthorizedSpiffeIDs is empty, any trust domain with a bundle is allowed if empty" split_words:"true"`
+	InsecureMode          bool     `default:"false" desc:"Run without SPIFFE using insecure connections, for testing only" split_words:"true"`
+	TLSMinVersion         string   `default:"1.2" desc:"Minimum TLS version of the connection to NSMgr: 1.2 or 1.3" envconfig:"tls_min_version"`
+	TLSCipherSuites       []string `default:"" desc:"A list of TLS 1.2 cipher suites allowed for the connection to NSMgr, e.g. TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, the Go defaults are used if empty" envconfig:"tls_cipher_suites"`
+
+	ConnectionIDSuffix     string `default:"" desc:"Unique suffix for connection IDs, e.g. pod UID from downward API" split_words:"true"`
+	ConnectionIDSuffixFile string `default:"" desc:"File to persist a generated connection ID suffix across restarts, used if ConnectionIDSuffix is not set" split_words:"true"`
+
+	KeepaliveTime                time.Duration `default:"5m" desc:"interval of gRPC keepalive pings to NSMgr, should not be less than the server enforcement minimum time" split_words:"true"`
+	KeepaliveTimeout             time.Duration `default:"20s" desc:"timeout to wait for a gRPC keepalive ping ack before closing the connection to NSMgr" split_words:"true"`
+	KeepalivePermitWithoutStream bool          `default:"false" desc:"send gRPC keepalive pings to NSMgr even without active streams" split_words:"true"`
+
+	ConnectToFallbacks []url.URL `default:"" desc:"A list of NSMgr urls to fail over to in order if the current one fails" split_words:"true"`
+
+	InterfaceName string `default:"" desc:"Name of the kernel interfaces if it is not set in the NSURL, the index is appended if several services use it, generated if empty, ${VAR} and $VAR are substituted with the environment variables" split_words:"true"`
+
+	LinkUpTimeout time.Duration `default:"0s" desc:"timeout for the link of a client interface to come up after Request, the connection is failed and retried on expiry, disabled if 0" split_words:"true"`
+
+	Routes serviceRoutes `default:"" desc:"A list of [SERVICE=]CIDR[@VIA] routes added to the client side of the connections to SERVICE or to all connections if SERVICE is not set" split_words:"true"`
+
+	InterfaceMTU uint32 `default:"0" desc:"MTU of the client interfaces, the NSE may lower it, 9000 is used if 0" envconfig:"interface_mtu"`
+
+	BandwidthMbps uint32 `default:"0" desc:"Bandwidth in Mbps requested for each connection and policed on the client interfaces, disabled if 0" split_words:"true"`
+
+	DSCP int `default:"-1" desc:"DSCP value marked on the traffic sent by the client interfaces, can be overridden per network service with the dscp NSURL parameter, not sent to NSMgr, disabled if -1" envconfig:"dscp"`
+
+	Payload string `default:"" desc:"Payload of the connections: ETHERNET or IP, the default of the mechanism is used if empty" envconfig:"payload"`
+
+	EnableHeal             bool `default:"true" desc:"Heal the connections if NSMgr, NSE or the dataplane fail" split_words:"true"`
+	EnableUpstreamRefresh  bool `default:"true" desc:"Refresh the connections on upstream refresh requests from NSMgr" split_words:"true"`
+	EnableExcludedPrefixes bool `default:"true" desc:"Send the excluded prefixes from ExcludedPrefixesFile and awareness groups with the requests" split_words:"true"`
+	UpstreamRefreshLocal   bool `default:"false" desc:"Refresh all connections if any of them receives an upstream refresh request, only the requested one is refreshed if false" split_words:"true"`
+
+	RetryInterval    time.Duration `default:"200ms" desc:"delay before the first retry of a failed request to NSMgr" split_words:"true"`
+	RetryMaxInterval time.Duration `default:"30s" desc:"upper bound of the delay between retries" split_words:"true"`
+	RetryMultiplier  float64       `default:"2" desc:"factor the delay between retries is multiplied by after each failed try" split_words:"true"`
+	RetryJitter      float64       `default:"0.2" desc:"fraction of the delay between retries it is randomly changed by, in [0, 1]" split_words:"true"`
+	RetryMaxRetries  int           `default:"0" desc:"maximum number of retries of a request to NSMgr, unlimited if 0, can be overridden per network service with the maxRetries NSURL parameter" split_words:"true"`
+
+	StatusFile     string `default:"" desc:"Path to a JSON file listing the established connections, removed on clean shutdown, disabled if empty" split_words:"true"`
+	InterfacesFile string `default:"" desc:"Path to a JSON file mapping the network services to the VPP and host interfaces of their connections, removed on shutdown, disabled if empty" split_words:"true"`
+
+	IPFamily string `default:"" desc:"IP family of the source addresses required for each connection: ipv4, ipv6 or dualstack, not checked if empty" split_words:"true"`
+
+	DryRun bool `default:"false" desc:"Validate config, print the requests that would be sent and exit without starting VPP" split_words:"true"`
+
+	MaxConnectionLifetime time.Duration `default:"0s" desc:"interval to close and request again each connection to get a fresh path, disabled if 0" split_words:"true"`
+
+	StartupTimeout time.Duration `default:"0s" desc:"timeout for phases 2-5 of the startup, the client stops and exits with the phase in progress logged on expiry, disabled if 0" split_words:"true"`
+
+	GracefulShutdownTimeout time.Duration `default:"15s" desc:"timeout to close all connections on shutdown" split_words:"true"`
+	ShutdownConcurrency     int           `default:"1" desc:"maximum number of connections closed at once on shutdown, in reverse order of establishment" split_words:"true"`
+	ShutdownCloseTimeout    time.Duration `default:"0s" desc:"timeout to close each connection on shutdown, limited only by the graceful shutdown timeout if 0" split_words:"true"`
+
+	VppStatsSocket         string        `default:"/var/run/vpp/stats.sock" desc:"VPP stats socket" split_words:"true"`
+	InterfaceStatsInterval time.Duration `default:"0s" desc:"interval between polls of VPP interface stats logged at debug level, disabled if 0" split_words:"true"`
+
+	VppConfigPath        string   `default:"" desc:"Path to a VPP startup config template used instead of the default one" split_words:"true"`
+	VppBootstrapCommands []string `default:"" desc:"A list of vppctl commands executed right after VPP is started" split_words:"true"`
+	VppCPUList           string   `default:"" desc:"CPUs to pin the VPP threads to, e.g. 2-4,8: the main thread runs on the first one, a worker on each of the others" envconfig:"vpp_cpu_list"`
+	VppWorkers           int      `default:"0" desc:"Number of VPP workers pinned to the CPUs next to the one of the main thread by VPP, used if VppCPUList is empty" split_words:"true"`
+
+	RestartVppOnFailure   bool          `default:"false" desc:"restart VPP and request all connections again if VPP dies, otherwise the client exits" split_words:"true"`
+	VppMaxRestarts        int           `default:"0" desc:"Maximum number of VPP restarts, including the failed starts, the client exits once VPP dies after them, unlimited if 0" split_words:"true"`
+	VppRestartInterval    time.Duration `default:"1s" desc:"Delay before the first VPP restart, doubled on each restart up to VppRestartMaxInterval and reset once VPP runs for VppRestartMaxInterval" split_words:"true"`
+	VppRestartMaxInterval time.Duration `default:"30s" desc:"Upper bound of the delay before a VPP restart" split_words:"true"`
+
+	MonitorSocket string `default:"" desc:"unix socket path to serve the MonitorConnection API with the state of the client connections for the other containers of the pod, disabled if empty" split_words:"true"`
+
+	CleanupStaleConnections bool `default:"false" desc:"close the connections of the previous instances of the client to the network services which are not requested anymore on startup" split_words:"true"`
+
+	ExtraContext keyValues `default:"" desc:"Extra context in KEY=VALUE form added to each connection, the extra-KEY=VALUE NSURL parameters take precedence on conflict" split_words:"true"`
+
+	WaitForNSMgr bool `default:"false" desc:"keep retrying to dial NSMgr and request all connections with backoff if it is unavailable, otherwise the client exits" envconfig:"wait_for_nsmgr"`
+
+	SvidWaitTimeout  time.Duration `default:"1m" desc:"maximum time to wait for the SPIRE agent to provide the SVID on startup, the client exits on expiry" split_words:"true"`
+	SpiffeSocketPath string        `default:"" desc:"Path to the SPIFFE workload API socket, the SPIFFE_ENDPOINT_SOCKET address is used if empty" split_words:"true"`
+
+	LogConnectionEvents bool `default:"false" desc:"log each state transition of the connections: requested, up, healed, down and closed" split_words:"true"`
+	LogConnectionPath   bool `default:"false" desc:"log the path segments of the connections after each successful request" split_words:"true"`
+
+	Zone        string `default:"" desc:"Zone of the node, e.g. set from the downward API, added to each connection as the ZoneLabel label so NSEs in the same zone are preferred, not added if empty" split_words:"true"`
+	Region      string `default:"" desc:"Region of the node added to each connection as the RegionLabel label, not added if empty" split_words:"true"`
+	ZoneLabel   string `default:"topology.kubernetes.io/zone" desc:"Label key the zone is added with, should match the NSE matches rules" split_words:"true"`
+	RegionLabel string `default:"topology.kubernetes.io/region" desc:"Label key the region is added with, should match the NSE matches rules" split_words:"true"`
+
+	VlanDevices keyValues `default:"" desc:"VIA=DEVICE pairs mapping the via NSURL label of the vlan:// network services to the VPP interfaces the VLAN subinterfaces are created on" split_words:"true"`
+
+	EnableWatchdog    bool          `default:"false" desc:"Close and request again the connections which stay down for longer than WatchdogThreshold, e.g. after the heal has given up" split_words:"true"`
+	WatchdogThreshold time.Duration `default:"2m" desc:"How long a connection may stay down before the watchdog requests it again" split_words:"true"`
+
+	ConnectToSRV string `default:"" desc:"DNS SRV record of NSMgr, e.g. _nsmgr._tcp.nsm-system.svc.cluster.local, resolved to host:port of the tcp connection to NSMgr and again if it fails to dial, ignored if ConnectTo is set" envconfig:"connect_to_srv"`
+
+	MechanismParameters keyValues `default:"" desc:"Raw KEY=VALUE parameters added to the mechanism of each connection with an interface, the parameters set by the client from the other options take precedence, ${VAR} and $VAR in the values are substituted with the environment variables" split_words:"true"`
+
+	Mode         string `default:"standalone" desc:"standalone to start VPP and connect the network services, init to only start VPP and run the bootstrap commands, run to connect the network services through VPP started in init mode" envconfig:"mode"`
+	VppAPISocket string `default:"/var/run/vpp/api.sock" desc:"API socket of VPP started in init mode, used in run mode" envconfig:"vpp_api_socket"`
+
+	NodeNameEnv    string `default:"NODE_NAME" desc:"Environment variable with the node name sent as the nodeName label of the connections" split_words:"true"`
+	PodNameEnv     string `default:"POD_NAME" desc:"Environment variable with the pod name sent as the podName label of the connections" split_words:"true"`
+	ClusterNameEnv string `default:"CLUSTER_NAME" desc:"Environment variable with the cluster name sent as the clusterName label of the connections" split_words:"true"`
+
+	AllConnectedFile string `default:"" desc:"Path to a file written with the connection IDs and interfaces each time all network services are connected and removed while some of them is not, disabled if empty" split_words:"true"`
+
+	PacketCapture           string        `default:"" desc:"Directory to write the pcap files of the interfaces of the network services with the capture NSURL parameter to, disabled if empty" split_words:"true"`
+	PacketCaptureMaxPackets int           `default:"10000" desc:"Maximum number of packets of each packet capture" split_words:"true"`
+	PacketCaptureDuration   time.Duration `default:"0s" desc:"Duration of each packet capture, the capture runs until the connection is closed if 0" split_words:"true"`
+
+	HealthLogInterval time.Duration `default:"0s" desc:"Interval between the logs of the state, the uptime and the interface counters of all connections, disabled if 0" split_words:"true"`
+
+	StateDump     bool   `default:"false" desc:"Dump the state of the client on SIGQUIT instead of stopping" split_words:"true"`
+	StateDumpFile string `default:"" desc:"Path to the file the state is dumped to, stderr if empty" split_words:"true"`
+
+	PinnedNSEFallback bool `default:"false" desc:"Request the network service from any of its NSEs if the request to the NSE pinned by the nse NSURL parameter fails" split_words:"true"`
+
+	IdleTimeout time.Duration `default:"0s" desc:"Close the connections with no packets received or sent through their VPP interfaces for this long, disabled if 0" split_words:"true"`
+
+	InitialDelay time.Duration `default:"0s" desc:"Delay before the first request of the connections to let the control plane settle, counted in StartupTimeout, disabled if 0" split_words:"true"`
+
+	ResumePolicy string `default:"auto" desc:"auto to resume the connections known to NSMgr and request the others from scratch, require to fail the startup requests of the connections not known to NSMgr, never to always request the connections from scratch" split_words:"true"`
+
+	WatchInterfaces       []string      `default:"" desc:"A list of the interfaces of the client network namespace, e.g. eth0 set up by the CNI, which creation, deletion or link state change triggers WatchInterfacesAction" split_words:"true"`
+	WatchInterfacesAction string        `default:"request" desc:"request to close all connections and request them again from scratch, exit to exit, so the pod is restarted" split_words:"true"`
+	WatchInterfacesSettle time.Duration `default:"2s" desc:"The changes of the watched interfaces are acted on once no other change follows for this long" split_words:"true"`
+
+	ReconcileInterval time.Duration `default:"0s" desc:"Interval of the reconcile requesting again the connections which are not up or missing, an alternative to heal which requires EnableHeal=false, disabled if 0" split_words:"true"`
+
+	AllowNoHugepages bool `default:"false" desc:"Run VPP on 4K pages if there are no free hugepages on the node, otherwise the client fails at startup" split_words:"true"`
+
+	PreferredIPFile string `default:"" desc:"Path to the JSON file mapping the network services to the source IPs requested as a hint, the IPs the connections get are stored to it" envconfig:"preferred_ip_file"`
+
+	MetricsFile string `default:"" desc:"Path to the file written with the metrics in the OpenMetrics text format each MetricsExportInterval and truncated on shutdown, disabled if empty" split_words:"true"`
+
+	MaxInFlightRequests int `default:"4" desc:"Maximum number of requests to NSMgr in flight at once, including the refreshes and the heals, the others are queued" split_words:"true"`
+
+	VppAPITraceSize int    `default:"0" desc:"Number of the recent VPP API calls kept to be dumped when a request fails, disabled if 0" envconfig:"vpp_api_trace_size"`
+	VppAPITraceDir  string `default:"" desc:"Directory to write the VPP API calls dumped when a request fails to, logged if empty" envconfig:"vpp_api_trace_dir"`
+
+	Tenant string `default:"" desc:"Tenant ID added to the metrics, the spans and the status and interfaces files of the connections for accounting, can be overridden per network service with the tenant NSURL parameter, not sent to NSMgr" split_words:"true"`
+
+	MemifRingSize   uint32 `default:"0" desc:"Number of entries of the RX/TX rings of the memif interfaces, a power of two, the VPP default of 1024 is used if 0" split_words:"true"`
+	MemifBufferSize uint16 `default:"0" desc:"Size of the buffer of each memif ring entry in bytes, a power of two, the VPP default of 2048 is used if 0" split_words:"true"`
+	MemifRole       string `default:"auto" desc:"Role of the client memif interfaces: master, slave or auto to take the role returned by the NSE or the forwarder, slave if none is returned" split_words:"true"`
+	MemifSocketDir  string `default:"" desc:"Existing writable directory to create the sockets of the memif interfaces the client is the master of in, shared with the peer, the socket returned by the NSE or the forwarder is used if empty" split_words:"true"`
+}
+
+// keyValues - map decoded from a comma separated list of KEY=VALUE pairs
+type keyValues map[string]string
+
+// Decode - implements envconfig.Decoder
+func (kv *keyValues) Decode(value string) error {
+	*kv = make(keyValues)
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if k = strings.TrimSpace(k); !ok || k == "" {
+			return errors.Errorf("invalid key-value pair %q, expected KEY=VALUE", pair)
+		}
+		(*kv)[k] = strings.TrimSpace(v)
+	}
+	return nil
+}
+
+// serviceRoutes - routes per network service decoded from a comma separated list of [SERVICE=]CIDR[@VIA] entries,
+// the routes without a service are stored with the empty key
+type serviceRoutes map[string][]*networkservice.Route
+
+// Decode - implements envconfig.Decoder
+func (r *serviceRoutes) Decode(value string) error {
+	*r = make(serviceRoutes)
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	prefixes := make(map[string]struct{})
+	for _, entry := range strings.Split(value, ",") {
+		service, route, ok := strings.Cut(entry, "=")
+		if !ok {
+			service, route = "", entry
+		}
+		service = strings.TrimSpace(service)
+		prefix, via, hasVia := strings.Cut(strings.TrimSpace(route), "@")
+		_, ipNet, err := net.ParseCIDR(prefix)
+		if err != nil {
+			return errors.Errorf("invalid route %q, expected [SERVICE=]CIDR[@VIA]: %s", entry, err.Error())
+		}
+		if hasVia {
+			ip := net.ParseIP(via)
+			if ip == nil {
+				return errors.Errorf("invalid route %q: invalid via address %q", entry, via)
+			}
+			if (ip.To4() == nil) != (ipNet.IP.To4() == nil) {
+				return errors.Errorf("invalid route %q: via address and prefix are of different IP families", entry)
+			}
+		}
+		// The same prefix may be set several times for a service, only the first route is used
+		if _, ok := prefixes[service+"="+ipNet.String()]; ok {
+			continue
+		}
+		prefixes[service+"="+ipNet.String()] = struct{}{}
+		(*r)[service] = append((*r)[service], &networkservice.Route{
+			Prefix:  ipNet.String(),
+			NextHop: via,
+		})
+	}
+	return nil
+}
+
+// expandName - substitutes the environment variables referenced in Name, fails if any of them is unset
+func (c *Config) expandName() error {
+	var err error
+	c.Name, err = expandEnv("Name", c.Name)
+	return err
+}
+
+// loadAwarenessGroupsFiles - reads the awareness groups of AwarenessGroupsFiles and merges them into AwarenessGroups
+func (c *Config) loadAwarenessGroupsFiles() error {
+	sources := [][][]*url.URL{c.AwarenessGroups}
+	for _, path := range c.AwarenessGroupsFiles {
+		data, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return errors.Wrapf(err, "failed to read awareness groups file %s", path)
+		}
+		// The decoder drops the spaces only, so the groups may be split across lines
+		var groups awarenessgroups.Decoder
+		if err = groups.Decode(strings.Join(strings.Fields(string(data)), "")); err != nil {
+			return errors.Wrapf(err, "invalid awareness groups file %s", path)
+		}
+		sources = append(sources, groups)
+	}
+	c.AwarenessGroups = mergeAwarenessGroups(sources...)
+	return nil
+}
+
+// mergeAwarenessGroups - returns the groups of all sources in order, the groups sharing an NSURL are merged into the
+// first of them, as all of their NSEs are aware of the NSE of the shared NSURL. The duplicate NSURLs are dropped.
+func mergeAwarenessGroups(sources ...[][]*url.URL) [][]*url.URL {
+	var merged [][]*url.URL
+	// groupOf - index of the merged group of each NSURL
+	groupOf := make(map[string]int)
+	for _, groups := range sources {
+		for _, group := range groups {
+			target := -1
+			for _, u := range group {
+				if i, ok := groupOf[u.String()]; ok && (target == -1 || i < target) {
+					target = i
+				}
+			}
+			if target == -1 {
+				target = len(merged)
+				merged = append(merged, nil)
+			}
+			for _, u := range group {
+				i, ok := groupOf[u.String()]
+				switch {
+				case !ok:
+					merged[target] = append(merged[target], u)
+					groupOf[u.String()] = target
+				case i != target:
+					// Another group shares an NSURL with this one, it is moved into the target group
+					for _, moved := range merged[i] {
+						merged[target] = append(merged[target], moved)
+						groupOf[moved.String()] = target
+					}
+					merged[i] = nil
+				}
+			}
+		}
+	}
+	var result [][]*url.URL
+	for _, group := range merged {
+		if group != nil {
+			result = append(result, group)
+		}
+	}
+	return result
+}
+
+// expandMechanismParameters - substitutes the environment variables referenced in InterfaceName, the values of
+// MechanismParameters and the interface names in the NSURL paths, so they can differ per pod, fails if any of them is
+// unset
+func (c *Config) expandMechanismParameters() error {
+	var err error
+	if c.InterfaceName, err = expandEnv("InterfaceName", c.InterfaceName); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(c.MechanismParameters))
+	for key := range c.MechanismParameters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if c.MechanismParameters[key], err = expandEnv("MechanismParameters "+key, c.MechanismParameters[key]); err != nil {
+			return err
+		}
+	}
+	return expandInterfaceNames(c.NetworkServices)
+}
+
+// expandInterfaceNames - substitutes the environment variables referenced in the paths of the NSURLs, the last path
+// segment is the interface name
+func expandInterfaceNames(services []url.URL) error {
+	for i := range services {
+		path, err := expandEnv("NSURL path "+services[i].Path, services[i].Path)
+		if err != nil {
+			return err
+		}
+		services[i].Path, services[i].RawPath = path, ""
+	}
+	return nil
+}
+
+// expandEnv - substitutes the environment variables referenced in value as ${VAR} or $VAR, fails if any of them is
+// unset
+func expandEnv(field, value string) (string, error) {
+	var unset []string
+	expanded := os.Expand(value, func(key string) string {
+		env, ok := os.LookupEnv(key)
+		if !ok {
+			unset = append(unset, key)
+		}
+		return env
+	})
+	if len(unset) > 0 {
+		return "", errors.Errorf("%s references unset environment variables: %s", field, strings.Join(unset, ", "))
+	}
+	return expanded, nil
+}
+
+// normalizeConnectTo - checks the schemes of ConnectTo and ConnectToFallbacks and brings them to the form grpcutils.URLToTarget expects
+func (c *Config) normalizeConnectTo() error {
+	if err := normalizeNSMgrURL(&c.ConnectTo, c.ConnectTo.Scheme == srv.Scheme); err != nil {
+		return errors.Wrap(err, "invalid ConnectTo")
+	}
+	for i := range c.ConnectToFallbacks {
+		if err := normalizeNSMgrURL(&c.ConnectToFallbacks[i], false); err != nil {
+			return errors.Wrap(err, "invalid ConnectToFallbacks")
+		}
+	}
+	return nil
+}
+
+// normalizeNSMgrURL - checks u is a unix, tcp or vsock URL, srv is only allowed if it is set from ConnectToSRV
+func normalizeNSMgrURL(u *url.URL, allowSRV bool) error {
+	switch u.Scheme {
+	case "unix":
+		// unix:relative/path is parsed as opaque and dialed as is
+		if u.Opaque != "" {
+			return nil
+		}
+		// unix://var/run/nsm.sock is a common typo of unix:///var/run/nsm.sock
+		if u.Host != "" {
+			return errors.Errorf("%s has a host %q, the socket path should follow unix:// as in unix:///path", u.String(), u.Host)
+		}
+		if u.Path == "" {
+			return errors.Errorf("%s has no socket path", u.String())
+		}
+	case "tcp":
+		if u.Hostname() == "" {
+			return errors.Errorf("%s has no host", u.String())
+		}
+		if u.Port() == "" {
+			u.Host = net.JoinHostPort(u.Hostname(), defaultNSMgrPort)
+		}
+		if _, err := strconv.ParseUint(u.Port(), 10, 16); err != nil {
+			return errors.Errorf("%s has an invalid port %q", u.String(), u.Port())
+		}
+		if u.Path != "" && u.Path != "/" {
+			return errors.Errorf("%s has a path, only tcp://host:port is supported", u.String())
+		}
+		u.Path = ""
+	case vsock.Scheme:
+		if _, _, err := vsock.Parse(u); err != nil {
+			return err
+		}
+	case srv.Scheme:
+		if !allowSRV {
+			return errors.Errorf("%s: SRV records are only supported with NSM_CONNECT_TO_SRV", u.String())
+		}
+		if u.Host == "" {
+			return errors.Errorf("%s has no SRV record", u.String())
+		}
+	default:
+		return errors.Errorf("%q has unsupported scheme %q, supported schemes are unix, tcp and vsock", u.String(), u.Scheme)
+	}
+	return nil
+}
+
+// validate - checks the config values that can't be checked by envconfig itself
+func (c *Config) validate() error {
+	var errs []string
+	for i := range c.NetworkServices {
+		if err := validateNetworkService(&c.NetworkServices[i]); err != nil {
+			errs = append(errs, fmt.Sprintf("%q: %s", c.NetworkServices[i].String(), err.Error()))
+		}
+	}
+	suppliedIDs := make(map[string]bool)
+	for i := range c.NetworkServices {
+		id := c.NetworkServices[i].Query().Get(connectionIDParam)
+		if id == "" {
+			continue
+		}
+		if err := c.validateConnectionID(id); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", c.NetworkServices[i].String(), err.Error()))
+		}
+		if suppliedIDs[id] {
+			errs = append(errs, fmt.Sprintf("%s: connection ID %s is set for another network service", c.NetworkServices[i].String(), id))
+		}
+		suppliedIDs[id] = true
+	}
+	// The preferred IPs are stored per network service
+	preferredIPServices := make(map[string]bool)
+	for i := range c.NetworkServices {
+		service := (*nsurl.NSURL)(&c.NetworkServices[i]).NetworkService()
+		if c.PreferredIPFile != "" && preferredIPServices[service] {
+			errs = append(errs, fmt.Sprintf("%s: network service %s is requested more than once, the preferred IP file "+
+				"needs one connection per network service", c.NetworkServices[i].String(), service))
+		}
+		preferredIPServices[service] = true
+	}
+	for i := range c.NetworkServices {
+		mech := (*nsurl.NSURL)(&c.NetworkServices[i]).Mechanism()
+		if name := c.interfaceName(i); mech.Type == kernel.MECHANISM && len(name) > kernel.LinuxIfMaxLength {
+			errs = append(errs, fmt.Sprintf("%s: kernel interface name %s is longer than %d", c.NetworkServices[i].String(), name, kernel.LinuxIfMaxLength))
+		}
+		if via := (*nsurl.NSURL)(&c.NetworkServices[i]).Labels()[vlan.ViaLabel]; mech.Type == vlanmech.MECHANISM && c.VlanDevices[via] == "" {
+			errs = append(errs, fmt.Sprintf("%s: no VLAN device for %s label %q", c.NetworkServices[i].String(), vlan.ViaLabel, via))
+		}
+		if c.packetCapture(i) && c.PacketCapture == "" {
+			errs = append(errs, fmt.Sprintf("%s: packet capture directory is not set for %s NSURL parameter", c.NetworkServices[i].String(), captureParam))
+		}
+		if !supportedPayload(mech.Type, c.Payload) {
+			errs = append(errs, fmt.Sprintf("%s: payload %s is not supported by %s mechanism", c.NetworkServices[i].String(), c.Payload, mech.Type))
+		}
+		if c.NetworkServices[i].Query().Get(macParam) != "" && (mech.Type != memif.MECHANISM && mech.Type != kernel.MECHANISM || c.Payload != payload.Ethernet) {
+			errs = append(errs, fmt.Sprintf("%s: %s NSURL parameter needs memif or kernel mechanism with %s payload", c.NetworkServices[i].String(), macParam, payload.Ethernet))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Errorf("invalid network services: %s", strings.Join(errs, "; "))
+	}
+	if c.InterfaceMTU != 0 && (c.InterfaceMTU < minInterfaceMTU || c.InterfaceMTU > maxInterfaceMTU) {
+		return errors.Errorf("invalid interface MTU %d, should be in [%d, %d]", c.InterfaceMTU, minInterfaceMTU, maxInterfaceMTU)
+	}
+	if c.BandwidthMbps > maxBandwidthMbps {
+		return errors.Errorf("invalid bandwidth %d Mbps, should not be greater than %d Mbps", c.BandwidthMbps, maxBandwidthMbps)
+	}
+	if c.DSCP != policer.NoDSCP && (c.DSCP < 0 || c.DSCP > maxDSCP) {
+		return errors.Errorf("invalid DSCP %d, should be in [0, %d] or %d to disable marking", c.DSCP, maxDSCP, policer.NoDSCP)
+	}
+	if c.MemifRingSize != 0 && !isMemifSize(c.MemifRingSize, minMemifRingSize, maxMemifRingSize) {
+		return errors.Errorf("invalid memif ring size %d, should be a power of two in [%d, %d]", c.MemifRingSize, minMemifRingSize, maxMemifRingSize)
+	}
+	if c.MemifBufferSize != 0 && !isMemifSize(uint32(c.MemifBufferSize), minMemifBufferSize, maxMemifBufferSize) {
+		return errors.Errorf("invalid memif buffer size %d, should be a power of two in [%d, %d]", c.MemifBufferSize, minMemifBufferSize, maxMemifBufferSize)
+	}
+	switch c.MemifRole {
+	case memifrole.Auto, memifrole.Master, memifrole.Slave:
+	default:
+		return errors.Errorf("invalid memif role %q, should be %s, %s or %s", c.MemifRole, memifrole.Auto, memifrole.Master, memifrole.Slave)
+	}
+	if c.MemifSocketDir != "" {
+		if err := validateWritableDir(c.MemifSocketDir); err != nil {
+			return errors.Wrap(err, "invalid memif socket directory")
+		}
+	}
+	for key := range c.MechanismParameters {
+		if option, ok := clientMechanismParams[key]; ok {
+			return errors.Errorf("mechanism parameter %s can't be set with MechanismParameters, it is set from %s", key, option)
+		}
+	}
+	switch c.Mode {
+	case modeStandalone, modeInit:
+	case modeRun:
+		if c.RestartVppOnFailure {
+			return errors.New("VPP can't be restarted in run mode, it is started by the init mode")
+		}
+		if c.VppCPUList != "" || c.VppWorkers != 0 {
+			return errors.New("VPP threads can't be pinned in run mode, it is started by the init mode")
+		}
+	default:
+		return errors.Errorf("invalid mode %q, should be %s, %s or %s", c.Mode, modeStandalone, modeInit, modeRun)
+	}
+	if c.PacketCapture != "" && !filepath.IsAbs(c.PacketCapture) {
+		return errors.Errorf("invalid packet capture directory %q, should be absolute", c.PacketCapture)
+	}
+	if c.VppAPITraceSize < 0 {
+		return errors.Errorf("invalid VPP API trace size %d, should not be negative", c.VppAPITraceSize)
+	}
+	if c.VppAPITraceDir != "" && c.VppAPITraceSize == 0 {
+		return errors.New("VPP API trace directory requires VPP API trace size")
+	}
+	if c.VppAPITraceDir != "" && !filepath.IsAbs(c.VppAPITraceDir) {
+		return errors.Errorf("invalid VPP API trace directory %q, should be absolute", c.VppAPITraceDir)
+	}
+	if c.PacketCaptureMaxPackets <= 0 {
+		return errors.Errorf("invalid packet capture max packets %d, should be positive", c.PacketCaptureMaxPackets)
+	}
+	if c.PacketCaptureDuration < 0 {
+		return errors.Errorf("invalid packet capture duration %v, should not be negative", c.PacketCaptureDuration)
+	}
+	if c.VppMaxRestarts < 0 {
+		return errors.Errorf("invalid VPP max restarts %d, should not be negative", c.VppMaxRestarts)
+	}
+	if c.VppRestartInterval < 0 {
+		return errors.Errorf("invalid VPP restart interval %v, should not be negative", c.VppRestartInterval)
+	}
+	if c.VppRestartMaxInterval < c.VppRestartInterval {
+		return errors.Errorf("invalid VPP restart max interval %v, should not be less than the VPP restart interval %v", c.VppRestartMaxInterval, c.VppRestartInterval)
+	}
+	if c.VppWorkers < 0 {
+		return errors.Errorf("invalid number of VPP workers %d, should not be negative", c.VppWorkers)
+	}
+	if c.VppCPUList != "" && c.VppWorkers != 0 {
+		return errors.New("VPP CPU list and VPP workers can't be set together, the workers run on the CPUs of the list")
+	}
+	if _, err := c.vppCPUs(); err != nil {
+		return err
+	}
+	for label, env := range c.clientInfoEnvs() {
+		if env == "" {
+			return errors.Errorf("environment variable of the %s label should not be empty", label)
+		}
+	}
+	if c.IdleTimeout < 0 {
+		return errors.Errorf("invalid idle timeout %v, should not be negative", c.IdleTimeout)
+	}
+	if c.IdleTimeout > 0 && c.InterfaceStatsInterval >= c.IdleTimeout {
+		return errors.Errorf("interface stats interval %v should be less than idle timeout %v", c.InterfaceStatsInterval,
+			c.IdleTimeout)
+	}
+	if c.HealthLogInterval < 0 {
+		return errors.Errorf("invalid health log interval %v, should not be negative", c.HealthLogInterval)
+	}
+	if c.InitialDelay < 0 {
+		return errors.Errorf("invalid initial delay %v, should not be negative", c.InitialDelay)
+	}
+	if c.StartupTimeout > 0 && c.InitialDelay >= c.StartupTimeout {
+		return errors.Errorf("initial delay %v should be less than startup timeout %v", c.InitialDelay, c.StartupTimeout)
+	}
+	if c.ReconcileInterval < 0 {
+		return errors.Errorf("invalid reconcile interval %v, should not be negative", c.ReconcileInterval)
+	}
+	if c.ReconcileInterval > 0 && c.EnableHeal {
+		return errors.New("reconcile interval requires heal to be disabled, they are alternative resilience models")
+	}
+	if c.UpstreamRefreshLocal && !c.EnableUpstreamRefresh {
+		return errors.New("upstream refresh local notifications require upstream refresh to be enabled")
+	}
+	switch c.WatchInterfacesAction {
+	case watchActionRequest, watchActionExit:
+	default:
+		return errors.Errorf("invalid watch interfaces action %q, should be %s or %s", c.WatchInterfacesAction,
+			watchActionRequest, watchActionExit)
+	}
+	if len(c.WatchInterfaces) > 0 && c.WatchInterfacesSettle <= 0 {
+		return errors.Errorf("invalid watch interfaces settle time %v, should be positive", c.WatchInterfacesSettle)
+	}
+	switch c.ResumePolicy {
+	case resumePolicyAuto, resumePolicyRequire, resumePolicyNever:
+	default:
+		return errors.Errorf("invalid resume policy %q, should be %s, %s or %s", c.ResumePolicy,
+			resumePolicyAuto, resumePolicyRequire, resumePolicyNever)
+	}
+	if _, err := c.tlsMinVersion(); err != nil {
+		return err
+	}
+	if _, err := c.tlsCipherSuites(); err != nil {
+		return err
+	}
+	if c.EnableWatchdog && c.WatchdogThreshold <= 0 {
+		return errors.Errorf("invalid watchdog threshold %s, should be positive", c.WatchdogThreshold)
+	}
+	if c.MaxInFlightRequests < 1 {
+		return errors.Errorf("invalid maximum in-flight requests %d, should be at least 1", c.MaxInFlightRequests)
+	}
+	if c.ShutdownConcurrency < 1 {
+		return errors.Errorf("invalid shutdown concurrency %d, should be at least 1", c.ShutdownConcurrency)
+	}
+	if c.Zone != "" && c.ZoneLabel == "" {
+		return errors.New("zone label should not be empty if zone is set")
+	}
+	if c.Region != "" && c.RegionLabel == "" {
+		return errors.New("region label should not be empty if region is set")
+	}
+	if c.SpiffeSocketPath != "" && !filepath.IsAbs(c.SpiffeSocketPath) {
+		return errors.Errorf("invalid SPIFFE socket path %q, should be absolute", c.SpiffeSocketPath)
+	}
+	if c.RetryMultiplier < 1 {
+		return errors.Errorf("invalid retry multiplier %v, should not be less than 1", c.RetryMultiplier)
+	}
+	if c.RetryJitter < 0 || c.RetryJitter > 1 {
+		return errors.Errorf("invalid retry jitter %v, should be in [0, 1]", c.RetryJitter)
+	}
+	if err := ipfamily.Validate(c.IPFamily); err != nil {
+		return err
+	}
+	if _, err := c.spiffeAuthorizer(spiffeid.TrustDomain{}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// spiffeAuthorizer - returns an authorizer allowing only AuthorizedSpiffeIDs or, if they are not set, the members of
+// trustDomain and FederatedTrustDomains or any ID if none of them is set
+func (c *Config) spiffeAuthorizer(trustDomain spiffeid.TrustDomain) (tlsconfig.Authorizer, error) {
+	federated, err := c.federatedTrustDomains()
+	if err != nil {
+		return nil, err
+	}
+	if len(c.AuthorizedSpiffeIDs) == 0 {
+		if len(federated) == 0 {
+			return tlsconfig.AuthorizeAny(), nil
+		}
+		allowed := append([]spiffeid.TrustDomain{trustDomain}, federated...)
+		return tlsconfig.AdaptMatcher(func(id spiffeid.ID) error {
+			for _, td := range allowed {
+				if id.MemberOf(td) {
+					return nil
+				}
+			}
+			return errors.Errorf("unexpected trust domain %q", id.TrustDomain())
+		}), nil
+	}
+	var ids []spiffeid.ID
+	for _, s := range c.AuthorizedSpiffeIDs {
+		id, err := spiffeid.FromString(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid authorized SPIFFE ID %q", s)
+		}
+		ids = append(ids, id)
+	}
+	return tlsconfig.AuthorizeOneOf(ids...), nil
+}
+
+// federatedTrustDomains - returns the trust domains of FederatedTrustDomains
+func (c *Config) federatedTrustDomains() ([]spiffeid.TrustDomain, error) {
+	var trustDomains []spiffeid.TrustDomain
+	for _, s := range c.FederatedTrustDomains {
+		td, err := spiffeid.TrustDomainFromString(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid federated trust domain %q", s)
+		}
+		trustDomains = append(trustDomains, td)
+	}
+	return trustDomains, nil
+}
+
+// validateWritableDir - checks that dir is an absolute path of an existing directory the client can create files in
+func validateWritableDir(dir string) error {
+	if !filepath.IsAbs(dir) {
+		return errors.Errorf("%q should be absolute", dir)
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return errors.Wrapf(err, "%q can't be accessed", dir)
+	}
+	if !info.IsDir() {
+		return errors.Errorf("%q is not a directory", dir)
+	}
+	if err := unix.Access(dir, unix.W_OK); err != nil {
+		return errors.Wrapf(err, "%q is not writable", dir)
+	}
+	return nil
+}
+
+// isMemifSize - returns true if size is a power of two in [minSize, maxSize]
+func isMemifSize(size, minSize, maxSize uint32) bool {
+	return size >= minSize && size <= maxSize && size&(size-1) == 0
+}
+
+// tlsMinVersion - returns the TLS version of TLSMinVersion
+func (c *Config) tlsMinVersion() (uint16, error) {
+	switch c.TLSMinVersion {
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, errors.Errorf("invalid TLS min version %q, should be 1.2 or 1.3", c.TLSMinVersion)
+	}
+}
+
+// tlsCipherSuites - returns the IDs of TLSCipherSuites, nil if it is empty. Only the secure TLS 1.2 suites known to
+// Go are allowed, the TLS 1.3 suites are not configurable.
+func (c *Config) tlsCipherSuites() ([]uint16, error) {
+	if len(c.TLSCipherSuites) == 0 {
+		return nil, nil
+	}
+	if c.TLSMinVersion == "1.3" {
+		return nil, errors.New("TLS cipher suites can't be set with TLS min version 1.3, the TLS 1.3 suites are not configurable")
+	}
+	known := make(map[string]*tls.CipherSuite)
+	for _, suite := range tls.CipherSuites() {
+		known[suite.Name] = suite
+	}
+	var ids []uint16
+	for _, name := range c.TLSCipherSuites {
+		suite, ok := known[name]
+		if !ok {
+			return nil, errors.Errorf("unknown or insecure TLS cipher suite %q", name)
+		}
+		if !supportsTLS12(suite) {
+			return nil, errors.Errorf("TLS cipher suite %s is TLS 1.3 only, the TLS 1.3 suites are not configurable", name)
+		}
+		ids = append(ids, suite.ID)
+	}
+	return ids, nil
+}
+
+func supportsTLS12(suite *tls.CipherSuite) bool {
+	for _, version := range suite.SupportedVersions {
+		if version == tls.VersionTLS12 {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMAC - returns the unicast EUI-48 MAC address s
+func parseMAC(s string) (net.HardwareAddr, error) {
+	mac, err := net.ParseMAC(s)
+	if err != nil || len(mac) != 6 {
+		return nil, errors.Errorf("invalid %s %q, should be an EUI-48 MAC address", macParam, s)
+	}
+	if mac[0]&1 != 0 {
+		return nil, errors.Errorf("invalid %s %q, should be a unicast MAC address", macParam, s)
+	}
+	return mac, nil
+}
+
+// supportedPayload - returns true if the interface of mechType can carry payloadType, the default payload of the
+// mechanism is used if payloadType is empty
+func supportedPayload(mechType, payloadType string) bool {
+	if payloadType == "" {
+		return true
+	}
+	switch mechType {
+	case memif.MECHANISM, kernel.MECHANISM, vlanmech.MECHANISM:
+		return payloadType == payload.Ethernet || payloadType == payload.IP
+	case none.MECHANISM:
+		// There is no interface, so the payload is not requested
+		return true
+	default:
+		return false
+	}
+}
+
+// supportedMechanism - returns true if the mechanism type can be requested
+func supportedMechanism(mechType string) bool {
+	switch mechType {
+	case memif.MECHANISM, kernel.MECHANISM, vlanmech.MECHANISM, none.MECHANISM:
+		return true
+	default:
+		return false
+	}
+}
+
+// interfaceName - returns the interface name requested for the index-th network service: the one from the NSURL or
+// InterfaceName for kernel mechanism. If the same name is requested for several network services, the index is
+// appended to it to keep the interfaces of the connections distinct.
+func (c *Config) interfaceName(index int) string {
+	requested := func(i int) string {
+		mech := (*nsurl.NSURL)(&c.NetworkServices[i]).Mechanism()
+		if name, ok := mech.GetParameters()[common.InterfaceNameKey]; ok {
+			return name
+		}
+		if mech.Type == kernel.MECHANISM {
+			return c.InterfaceName
+		}
+		return ""
+	}
+
+	result := requested(index)
+	if result == "" {
+		return ""
+	}
+	var count int
+	for i := range c.NetworkServices {
+		if requested(i) == result {
+			count++
+		}
+	}
+	if count > 1 {
+		result = fmt.Sprintf("%s-%d", result, index)
+	}
+	return result
+}
+
+// redacted - returns a copy of the config with the secrets hidden, so it can be logged
+func (c *Config) redacted() *Config {
+	redacted := *c
+	if redacted.AdminToken != "" {
+		redacted.AdminToken = "<redacted>"
+	}
+	return &redacted
+}
+
+// effective - returns the config with the secrets and the paths of the token file and the SPIFFE socket redacted as
+// a map of the field names to the values which are encoded to JSON as they are logged: the durations and the URLs as
+// strings
+func (c *Config) effective() map[string]interface{} {
+	redacted := c.redacted()
+	if redacted.TokenFile != "" {
+		redacted.TokenFile = "<redacted>"
+	}
+	if redacted.SpiffeSocketPath != "" {
+		redacted.SpiffeSocketPath = "<redacted>"
+	}
+	v := reflect.ValueOf(redacted).Elem()
+	fields := make(map[string]interface{}, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		fields[v.Type().Field(i).Name] = jsonValue(v.Field(i))
+	}
+	return fields
+}
+
+// jsonValue - returns v formatted with its String method if it has one, the URLs with the passwords redacted, the
+// slices element by element and the other values as they are
+func jsonValue(v reflect.Value) interface{} {
+	if v.CanAddr() {
+		switch value := v.Addr().Interface().(type) {
+		case *url.URL:
+			return value.Redacted()
+		case fmt.Stringer:
+			return value.String()
+		}
+	}
+	if v.Kind() == reflect.Slice && !v.IsNil() {
+		elems := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			elems = append(elems, jsonValue(v.Index(i)))
+		}
+		return elems
+	}
+	return v.Interface()
+}
+
+// clientInfoEnvs - returns the environment variables of the client info labels
+func (c *Config) clientInfoEnvs() map[string]string {
+	return map[string]string{
+		clientinfo.NodeNameLabel:    c.NodeNameEnv,
+		clientinfo.PodNameLabel:     c.PodNameEnv,
+		clientinfo.ClusterNameLabel: c.ClusterNameEnv,
+	}
+}
+
+// topologyLabels - returns the labels with the zone and the region of the node which are set
+func (c *Config) topologyLabels() map[string]string {
+	labels := make(map[string]string)
+	if c.Zone != "" {
+		labels[c.ZoneLabel] = c.Zone
+	}
+	if c.Region != "" {
+		labels[c.RegionLabel] = c.Region
+	}
+	return labels
+}
+
+// monitorDialTimeout - returns the timeout to dial NSMgr by the monitor client
+func (c *Config) monitorDialTimeout() time.Duration {
+	if c.MonitorDialTimeout > 0 {
+		return c.MonitorDialTimeout
+	}
+	return c.DialTimeout
+}
+
+// interfaceStatsInterval - returns the interval between the polls of the interface stats: InterfaceStatsInterval or,
+// if it is not set, the shortest of a fraction of IdleTimeout and HealthLogInterval, 0 if none is set
+func (c *Config) interfaceStatsInterval() time.Duration {
+	if c.InterfaceStatsInterval > 0 {
+		return c.InterfaceStatsInterval
+	}
+	var interval time.Duration
+	if c.IdleTimeout > 0 {
+		interval = c.IdleTimeout / idleChecksPerTimeout
+	}
+	if c.HealthLogInterval > 0 && (interval == 0 || c.HealthLogInterval < interval) {
+		interval = c.HealthLogInterval
+	}
+	return interval
+}
+
+// resumePolicy - returns the resume policy of the requests at startup or, if startup is false, of the later ones:
+// only the startup requests can require a connection known to NSMgr, the later ones follow a close or request new
+// connections
+func (c *Config) resumePolicy(startup bool) string {
+	if !startup && c.ResumePolicy == resumePolicyRequire {
+		return resumePolicyAuto
+	}
+	return c.ResumePolicy
+}
+
+// vppCPUs - returns the CPUs of VppCPUList in order without duplicates, nil if it is empty. Each of them should be
+// available to the process.
+func (c *Config) vppCPUs() ([]int, error) {
+	if c.VppCPUList == "" {
+		return nil, nil
+	}
+	var available unix.CPUSet
+	if err := unix.SchedGetaffinity(0, &available); err != nil {
+		return nil, errors.Wrap(err, "failed to get the available CPUs")
+	}
+	var cpus []int
+	seen := make(map[int]bool)
+	for _, item := range strings.Split(c.VppCPUList, ",") {
+		first, last, isRange := strings.Cut(strings.TrimSpace(item), "-")
+		from, err := strconv.Atoi(first)
+		to := from
+		if err == nil && isRange {
+			to, err = strconv.Atoi(last)
+		}
+		if err != nil || from < 0 || to < from {
+			return nil, errors.Errorf("invalid VPP CPU list %q, should be CPUs and ranges of them, e.g. 2-4,8", c.VppCPUList)
+		}
+		for cpu := from; cpu <= to; cpu++ {
+			if !available.IsSet(cpu) {
+				return nil, errors.Errorf("CPU %d of VPP CPU list %q is not available, %d CPUs are available", cpu,
+					c.VppCPUList, available.Count())
+			}
+			if !seen[cpu] {
+				seen[cpu] = true
+				cpus = append(cpus, cpu)
+			}
+		}
+	}
+	return cpus, nil
+}
+
+// requestTimeout - returns the timeout of the requests, the closes and the monitor of the index-th network service:
+// the one set by the requestTimeout NSURL parameter or RequestTimeout
+func (c *Config) requestTimeout(index int) time.Duration {
+	if value := c.NetworkServices[index].Query().Get(requestTimeoutParam); value != "" {
+		if timeout, err := time.ParseDuration(value); err == nil {
+			return timeout
+		}
+	}
+	return c.RequestTimeout
+}
+
+// maxRetries - returns the maximum number of retries of the requests and the closes of the index-th network service:
+// the one set by the maxRetries NSURL parameter or RetryMaxRetries
+func (c *Config) maxRetries(index int) int {
+	if maxRetries, err := strconv.Atoi(c.NetworkServices[index].Query().Get(maxRetriesParam)); err == nil {
+		return maxRetries
+	}
+	return c.RetryMaxRetries
+}
+
+// pingProbe - returns the ping probe of the index-th network service set by the ping NSURL parameters, nil if it is
+// not set
+func (c *Config) pingProbe(index int) *pingprobe.Probe {
+	query := c.NetworkServices[index].Query()
+	target := query.Get(pingParam)
+	if target == "" {
+		return nil
+	}
+	probe := &pingprobe.Probe{
+		Timeout: defaultPingTimeout,
+	}
+	if target != pingPeer {
+		probe.Target = net.ParseIP(target)
+	}
+	if timeout, err := time.ParseDuration(query.Get(pingTimeoutParam)); err == nil {
+		probe.Timeout = timeout
+	}
+	return probe
+}
+
+// serviceConnectionID - returns the ID of the connection to the index-th network service: the one set by the
+// connectionId NSURL parameter or the one derived from Name, idSuffix and index
+func (c *Config) serviceConnectionID(idSuffix string, index int) string {
+	if id := c.NetworkServices[index].Query().Get(connectionIDParam); id != "" {
+		return id
+	}
+	return connectionID(c.Name, idSuffix, index)
+}
+
+// validateConnectionID - checks the connection ID set by the connectionId NSURL parameter. It should start with the
+// Name prefix, so the connection is followed like the others, and should not end with the index of the derived IDs,
+// so it can't take the ID of another connection.
+func (c *Config) validateConnectionID(id string) error {
+	if !strings.HasPrefix(id, c.Name+"-") {
+		return errors.Errorf("connection ID %s should start with %s-", id, c.Name)
+	}
+	last := id[strings.LastIndex(id, "-")+1:]
+	if _, err := strconv.Atoi(last); err == nil {
+		return errors.Errorf("connection ID %s conflicts with the IDs derived from the name %s-[suffix-]index, it "+
+			"should not end with -%s", id, c.Name, last)
+	}
+	return nil
+}
+
+// packetCapture - returns true if the packet capture of the index-th network service is enabled by the capture NSURL
+// parameter
+func (c *Config) packetCapture(index int) bool {
+	capture, err := strconv.ParseBool(c.NetworkServices[index].Query().Get(captureParam))
+	return err == nil && capture
+}
+
+// tenant - returns the tenant of the index-th network service: the one set by the tenant NSURL parameter or Tenant
+func (c *Config) tenant(index int) string {
+	if tenant := c.NetworkServices[index].Query().Get(tenantParam); tenant != "" {
+		return tenant
+	}
+	return c.Tenant
+}
+
+// dscp - returns the DSCP value marked on the traffic of the index-th network service: the one set by the dscp NSURL
+// parameter or DSCP
+func (c *Config) dscp(index int) int {
+	if value := c.NetworkServices[index].Query().Get(policer.DSCPLabel); value != "" {
+		if dscp, err := strconv.Atoi(value); err == nil {
+			return dscp
+		}
+	}
+	return c.DSCP
+}
+
+// sourceMAC - returns the MAC address requested for the interface of the index-th network service, empty if it is
+// not set
+func (c *Config) sourceMAC(index int) string {
+	mac, err := parseMAC(c.NetworkServices[index].Query().Get(macParam))
+	if err != nil {
+		return ""
+	}
+	return mac.String()
+}
+
+func validateNetworkService(u *url.URL) error {
+	if u.Scheme == "" {
+		return errors.New("mechanism is not specified")
+	}
+	if mech := (*nsurl.NSURL)(u).Mechanism(); !supportedMechanism(mech.Type) {
+		return errors.Errorf("mechanism type: %v is not supported", mech.Type)
+	}
+	if (*nsurl.NSURL)(u).NetworkService() == "" {
+		return errors.New("network service name is empty")
+	}
+	if value := u.Query().Get(requestTimeoutParam); value != "" {
+		if timeout, err := time.ParseDuration(value); err != nil || timeout <= 0 {
+			return errors.Errorf("invalid %s %q, should be a positive duration", requestTimeoutParam, value)
+		}
+	}
+	if value := u.Query().Get(maxRetriesParam); value != "" {
+		if maxRetries, err := strconv.Atoi(value); err != nil || maxRetries < 0 {
+			return errors.Errorf("invalid %s %q, should be a non-negative integer", maxRetriesParam, value)
+		}
+	}
+	if value := u.Query().Get(pingParam); value != "" && value != pingPeer && net.ParseIP(value) == nil {
+		return errors.Errorf("invalid %s %q, should be an IP address or %s", pingParam, value, pingPeer)
+	}
+	if value := u.Query().Get(pingTimeoutParam); value != "" {
+		if timeout, err := time.ParseDuration(value); err != nil || timeout <= 0 {
+			return errors.Errorf("invalid %s %q, should be a positive duration", pingTimeoutParam, value)
+		}
+	}
+	if value := u.Query().Get(captureParam); value != "" {
+		if _, err := strconv.ParseBool(value); err != nil {
+			return errors.Errorf("invalid %s %q, should be true or false", captureParam, value)
+		}
+	}
+	if value := u.Query().Get(macParam); value != "" {
+		if _, err := parseMAC(value); err != nil {
+			return err
+		}
+	}
+	if value := u.Query().Get(vlanmech.ID); value != "" {
+		if vlanID, err := strconv.Atoi(value); err != nil || vlanID < 0 || vlanID > maxVlanID {
+			return errors.Errorf("invalid %s %q, should be in [0, %d]", vlanmech.ID, value, maxVlanID)
+		}
+	}
+	if value := u.Query().Get(policer.DSCPLabel); value != "" {
+		if dscp, err := strconv.Atoi(value); err != nil || dscp < 0 || dscp > maxDSCP {
+			return errors.Errorf("invalid %s %q, should be in [0, %d]", policer.DSCPLabel, value, maxDSCP)
+		}
+	}
+	return nil
+}
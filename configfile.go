@@ -0,0 +1,129 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/kelseyhightower/envconfig"
+	"github.com/pkg/errors"
+)
+
+// configFileEnv - environment variable of ConfigFile, it can't be set in the config file itself
+const configFileEnv = "NSM_CONFIG_FILE"
+
+// applyConfigFile - reads the JSON or YAML config file at path and sets the environment variables of its keys which
+// are not set yet, so envconfig.Process decodes them with the same defaults and decoders and the environment takes
+// precedence. The keys are the environment variables without the NSM_ prefix in any case, e.g. log_level, the values
+// are in the format of the environment variables, lists can also be given as lists. Fails on unknown keys.
+func applyConfigFile(path string) error {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return errors.Wrapf(err, "failed to read config file %s", path)
+	}
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return errors.Wrapf(err, "invalid config file %s", path)
+	}
+	var values map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+	if err = decoder.Decode(&values); err != nil {
+		return errors.Wrapf(err, "invalid config file %s, should be a map of the config keys to their values", path)
+	}
+
+	known, err := configEnvs()
+	if err != nil {
+		return err
+	}
+	envs := make(map[string]string, len(values))
+	var unknown []string
+	for key, value := range values {
+		env := "NSM_" + strings.ToUpper(key)
+		if !known[env] || env == configFileEnv {
+			unknown = append(unknown, key)
+			continue
+		}
+		if envs[env], err = configFileValue(value); err != nil {
+			return errors.Wrapf(err, "invalid value of %s in config file %s", key, path)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return errors.Errorf("unknown keys in config file %s: %s", path, strings.Join(unknown, ", "))
+	}
+	for env, value := range envs {
+		if _, ok := os.LookupEnv(env); ok {
+			continue
+		}
+		if err = os.Setenv(env, value); err != nil {
+			return errors.Wrapf(err, "failed to set %s from config file %s", env, path)
+		}
+	}
+	return nil
+}
+
+// configEnvs - returns the environment variables of the Config fields
+func configEnvs() (map[string]bool, error) {
+	var b strings.Builder
+	if err := envconfig.Usagef("nsm", &Config{}, &b, "{{range .}}{{.Key}}\n{{end}}"); err != nil {
+		return nil, errors.Wrap(err, "failed to list config environment variables")
+	}
+	envs := make(map[string]bool)
+	for _, env := range strings.Fields(b.String()) {
+		envs[env] = true
+	}
+	return envs, nil
+}
+
+// configFileValue - returns value of the config file as the value of an environment variable, lists are joined with
+// commas
+func configFileValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool, json.Number:
+		return fmt.Sprint(v), nil
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			if _, ok := item.([]interface{}); ok {
+				return "", errors.New("nested lists are not supported")
+			}
+			s, err := configFileValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil
+	default:
+		return "", errors.Errorf("%T is not supported, should be a string, a number, a boolean or a list", value)
+	}
+}
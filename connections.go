@@ -0,0 +1,546 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/connwatch"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/pingprobe"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/policer"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/spans"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// connectionStore - established connections in the order of establishment with the NSURLs and the indexes of their
+// network services, safe for concurrent use. The index tells apart the connections to identical NSURLs. The indexes
+// of the network services whose connections are closed for idleness are kept, so they are not reconciled.
+type connectionStore struct {
+	mu       sync.Mutex
+	ids      []string
+	conns    map[string]*networkservice.Connection
+	services map[string]string
+	indexes  map[string]int
+	idle     map[int]bool
+}
+
+func newConnectionStore() *connectionStore {
+	return &connectionStore{
+		conns:    make(map[string]*networkservice.Connection),
+		services: make(map[string]string),
+		indexes:  make(map[string]int),
+		idle:     make(map[int]bool),
+	}
+}
+
+// add - appends the connection to the index-th network service with the service NSURL
+func (s *connectionStore) add(index int, service string, conn *networkservice.Connection) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ids = append(s.ids, conn.GetId())
+	s.conns[conn.GetId()] = conn
+	s.services[conn.GetId()] = service
+	s.indexes[conn.GetId()] = index
+}
+
+// store - replaces the connection with the same ID
+func (s *connectionStore) store(conn *networkservice.Connection) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.conns[conn.GetId()]; ok {
+		s.conns[conn.GetId()] = conn
+	}
+}
+
+// load - returns the connection with the id
+func (s *connectionStore) load(id string) *networkservice.Connection {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.conns[id]
+}
+
+// delete - deletes the connection with the id
+func (s *connectionStore) delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deleteLocked(id)
+}
+
+func (s *connectionStore) deleteLocked(id string) {
+	for i := range s.ids {
+		if s.ids[i] == id {
+			s.ids = append(s.ids[:i], s.ids[i+1:]...)
+			break
+		}
+	}
+	delete(s.conns, id)
+	delete(s.services, id)
+	delete(s.indexes, id)
+}
+
+// list - returns all connections
+func (s *connectionStore) list() []*networkservice.Connection {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conns := make([]*networkservice.Connection, 0, len(s.ids))
+	for _, id := range s.ids {
+		conns = append(conns, s.conns[id])
+	}
+	return conns
+}
+
+// serviceOf - returns the NSURL of the network service of the connection with the id
+func (s *connectionStore) serviceOf(id string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.services[id]
+}
+
+// indexOf - returns the index of the network service of the connection with the id
+func (s *connectionStore) indexOf(id string) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index, ok := s.indexes[id]
+	return index, ok
+}
+
+// deleteIdle - deletes the connection with the id closed for idleness, its network service is skipped by the
+// reconciles until clearIdle
+func (s *connectionStore) deleteIdle(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if index, ok := s.indexes[id]; ok {
+		s.idle[index] = true
+	}
+	s.deleteLocked(id)
+}
+
+// isIdle - returns true if the connection to the index-th network service is closed for idleness
+func (s *connectionStore) isIdle(index int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.idle[index]
+}
+
+// idleCount - returns the number of the network services whose connections are closed for idleness
+func (s *connectionStore) idleCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.idle)
+}
+
+// clearIdle - forgets the network services whose connections are closed for idleness, e.g. when the network services
+// are reloaded, so they are reconciled again
+func (s *connectionStore) clearIdle() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.idle = make(map[int]bool)
+}
+
+// setIndex - sets the index of the network service of the connection with the id, e.g. after the network services
+// are reloaded
+func (s *connectionStore) setIndex(id string, index int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.conns[id]; ok {
+		s.indexes[id] = index
+	}
+}
+
+// serviceSettings - settings of the connections set by the NSURL parameters of their network services, read by the
+// chain elements, safe for concurrent use
+type serviceSettings struct {
+	mu          sync.RWMutex
+	tryTimeouts map[string]time.Duration
+	maxRetries  map[string]int
+	probes      map[string]*pingprobe.Probe
+	captures    map[string]bool
+	tenants     map[string]string
+	dscps       map[string]int
+}
+
+func newServiceSettings() *serviceSettings {
+	return &serviceSettings{
+		tryTimeouts: make(map[string]time.Duration),
+		maxRetries:  make(map[string]int),
+		probes:      make(map[string]*pingprobe.Probe),
+		captures:    make(map[string]bool),
+		tenants:     make(map[string]string),
+		dscps:       make(map[string]int),
+	}
+}
+
+// set - sets the settings of the connection with the id to the ones of the index-th network service of config
+func (s *serviceSettings) set(config *Config, index int, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tryTimeouts[id] = config.requestTimeout(index)
+	s.maxRetries[id] = config.maxRetries(index)
+	if probe := config.pingProbe(index); probe != nil {
+		s.probes[id] = probe
+	} else {
+		delete(s.probes, id)
+	}
+	if config.packetCapture(index) {
+		s.captures[id] = true
+	} else {
+		delete(s.captures, id)
+	}
+	if tenant := config.tenant(index); tenant != "" {
+		s.tenants[id] = tenant
+	} else {
+		delete(s.tenants, id)
+	}
+	s.dscps[id] = config.dscp(index)
+}
+
+// delete - deletes the settings of the connection with the id
+func (s *serviceSettings) delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.tryTimeouts, id)
+	delete(s.maxRetries, id)
+	delete(s.probes, id)
+	delete(s.captures, id)
+	delete(s.tenants, id)
+	delete(s.dscps, id)
+}
+
+// tryTimeout - returns the try timeout of the connection with the id
+func (s *serviceSettings) tryTimeout(id string) (time.Duration, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tryTimeout, ok := s.tryTimeouts[id]
+	return tryTimeout, ok
+}
+
+// maxRetriesOf - returns the maximum number of retries of the connection with the id
+func (s *serviceSettings) maxRetriesOf(id string) (int, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	maxRetries, ok := s.maxRetries[id]
+	return maxRetries, ok
+}
+
+// probe - returns the ping probe of the connection with the id, nil if it has none
+func (s *serviceSettings) probe(id string) *pingprobe.Probe {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.probes[id]
+}
+
+// capture - returns true if the packet capture of the connection with the id is enabled
+func (s *serviceSettings) capture(id string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.captures[id]
+}
+
+// tenant - returns the tenant of the connection with the id, empty if it has none
+func (s *serviceSettings) tenant(id string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.tenants[id]
+}
+
+// dscp - returns the DSCP value marked on the traffic of the connection with the id, policer.NoDSCP if it has none
+func (s *serviceSettings) dscp(id string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if dscp, ok := s.dscps[id]; ok {
+		return dscp
+	}
+	return policer.NoDSCP
+}
+
+// rotations - rotations of the connections, the ID of each connection which has reached its max lifetime is sent to
+// due, each rotation can be stopped separately
+type rotations struct {
+	dues chan string
+
+	mu    sync.Mutex
+	stops map[string]func()
+}
+
+func newRotations() *rotations {
+	return &rotations{
+		dues:  make(chan string),
+		stops: make(map[string]func()),
+	}
+}
+
+// due - returns the channel the IDs of the connections to rotate are sent to
+func (r *rotations) due() <-chan string {
+	return r.dues
+}
+
+// start - starts the rotation of the connection with the id, the ID is sent to due after lifetime unless ctx is done
+// or the rotation is stopped
+func (r *rotations) start(ctx context.Context, id string, lifetime time.Duration) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		timer := time.NewTimer(lifetime)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		select {
+		case <-ctx.Done():
+		case r.dues <- id:
+		}
+	}()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stops[id] = func() {
+		cancel()
+		<-done
+	}
+}
+
+// stop - stops the rotation of the connection with the id
+func (r *rotations) stop(id string) {
+	r.mu.Lock()
+	stop, ok := r.stops[id]
+	delete(r.stops, id)
+	r.mu.Unlock()
+
+	if ok {
+		stop()
+	}
+}
+
+// stopAll - stops the rotations of all connections
+func (r *rotations) stopAll() {
+	r.mu.Lock()
+	stops := r.stops
+	r.stops = make(map[string]func())
+	r.mu.Unlock()
+
+	for _, stop := range stops {
+		stop()
+	}
+}
+
+// rotateConnection - closes the connection with the id which has reached its max lifetime and requests it again from
+// scratch, so a fresh path is selected. The connection is rotated again after another lifetime if the request fails.
+// Should be called from the same goroutine as the requests of all connections.
+func rotateConnection(ctx, signalCtx context.Context, config *Config, id string,
+	monitorClient networkservice.MonitorConnectionClient, nsmClient networkservice.NetworkServiceClient,
+	connections *connectionStore, settings *serviceSettings, rotations *rotations) {
+	if connections.load(id) == nil {
+		// Closed by the client meanwhile
+		return
+	}
+	logger := log.FromContext(ctx).WithField("id", id)
+	logger.Infof("connection has reached max lifetime %s, requesting it again", config.MaxConnectionLifetime)
+
+	now := time.Now()
+	if err := requestConnectionAgain(ctx, signalCtx, config, id, monitorClient, nsmClient, connections, settings,
+		rotations); err != nil {
+		if signalCtx.Err() != nil {
+			logger.Info("shutdown is requested while requesting connection again")
+			return
+		}
+		logger.Errorf("failed to request connection again: %s", err.Error())
+		rotations.start(signalCtx, id, config.MaxConnectionLifetime)
+		return
+	}
+	logger.WithField("duration", time.Since(now)).Info("connection is requested again")
+}
+
+// closeStaleConnections - closes the connections left by the previous instances of the client, which are not
+// requested anymore: their ID starts with the name of the client, but is not in ids. The connections are found by the
+// monitor, each of them is closed through nsmClient in timeout.
+func closeStaleConnections(ctx, signalCtx context.Context, name string, ids []string,
+	monitorClient networkservice.MonitorConnectionClient, nsmClient networkservice.NetworkServiceClient, timeout time.Duration) {
+	monitorCtx, cancelMonitor := context.WithTimeout(signalCtx, timeout)
+	defer cancelMonitor()
+
+	stream, err := monitorClient.MonitorConnections(monitorCtx, &networkservice.MonitorScopeSelector{})
+	if err != nil {
+		log.FromContext(ctx).Errorf("failed to monitor connections to find the stale ones: %s", err.Error())
+		return
+	}
+	conns, err := connwatch.InitialState(monitorCtx, stream, monitorSettle)
+	if err != nil {
+		log.FromContext(ctx).Errorf("failed to receive connections to find the stale ones: %s", err.Error())
+		return
+	}
+
+	requested := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		requested[id] = true
+	}
+	for _, conn := range conns {
+		path := conn.GetPath()
+		if path.GetIndex() != 1 || len(path.GetPathSegments()) == 0 {
+			continue
+		}
+		id := path.GetPathSegments()[0].GetId()
+		if !strings.HasPrefix(id, name+"-") || requested[id] {
+			continue
+		}
+
+		logger := log.FromContext(ctx).WithField("id", id)
+		logger.Infof("closing stale connection to %s", conn.GetNetworkService())
+		conn.Path.Index = 0
+		conn.Id = id
+		closeCtx, cancelClose := context.WithTimeout(signalCtx, timeout)
+		_, err = nsmClient.Close(closeCtx, conn)
+		cancelClose()
+		if err != nil {
+			logger.Errorf("failed to close stale connection: %s", err.Error())
+		}
+	}
+}
+
+// closeConnections - closes the connections in reverse order of establishment in timeout, at most
+// ShutdownConcurrency at once and each of them in ShutdownCloseTimeout if it is set
+func closeConnections(ctx context.Context, config *Config, nsmClient networkservice.NetworkServiceClient,
+	connections []*networkservice.Connection, timeout time.Duration) {
+	// ctx may be already cancelled at this point, so the closes get their own budget
+	closeCtx, cancelClose := context.WithTimeout(context.Background(), timeout)
+	defer cancelClose()
+	closeCtx = log.WithLog(closeCtx, log.FromContext(ctx))
+
+	sem := make(chan struct{}, config.ShutdownConcurrency)
+
+	now := time.Now()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failed []string
+	for i := len(connections) - 1; i >= 0; i-- {
+		select {
+		case sem <- struct{}{}:
+		case <-closeCtx.Done():
+		}
+		if closeCtx.Err() != nil {
+			mu.Lock()
+			for _, conn := range connections[:i+1] {
+				failed = append(failed, conn.GetId())
+			}
+			mu.Unlock()
+			break
+		}
+
+		wg.Add(1)
+		go func(conn *networkservice.Connection) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := closeConnection(closeCtx, nsmClient, conn, config.ShutdownCloseTimeout); err != nil {
+				log.FromContext(ctx).Errorf("failed to close connection %s: %s", conn.GetId(), err.Error())
+				mu.Lock()
+				failed = append(failed, conn.GetId())
+				mu.Unlock()
+				return
+			}
+			log.FromContext(ctx).Infof("connection %s closed", conn.GetId())
+		}(connections[i])
+	}
+	wg.Wait()
+
+	logger := log.FromContext(ctx).WithField("duration", time.Since(now))
+	if len(failed) > 0 {
+		logger.Warnf("closed %d of %d connections, failed: %s", len(connections)-len(failed), len(connections), strings.Join(failed, ", "))
+		return
+	}
+	logger.Infof("closed all %d connections", len(connections))
+}
+
+// closeConnection - closes conn in timeout if it is set
+func closeConnection(ctx context.Context, nsmClient networkservice.NetworkServiceClient, conn *networkservice.Connection, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	spanCtx, span := spans.Start(ctx, "close",
+		spans.NetworkServiceKey.String(conn.GetNetworkService()),
+		spans.ConnectionIDKey.String(conn.GetId()),
+		spans.MechanismKey.String(conn.GetMechanism().GetType()))
+	_, err := nsmClient.Close(spanCtx, conn)
+	spans.End(span, err)
+	return err
+}
+
+// requestConnectionAgain - closes the connection with the id and requests it again from scratch. The connection is
+// left in connections if the request fails. Should be called from the same goroutine as the requests of all
+// connections.
+func requestConnectionAgain(ctx, signalCtx context.Context, config *Config, id string,
+	monitorClient networkservice.MonitorConnectionClient, nsmClient networkservice.NetworkServiceClient,
+	connections *connectionStore, settings *serviceSettings, rotations *rotations) error {
+	conn := connections.load(id)
+	if conn == nil {
+		return nil
+	}
+	index, ok := connections.indexOf(id)
+	if !ok || index >= len(config.NetworkServices) {
+		return nil
+	}
+
+	rotations.stop(id)
+	if err := closeConnection(ctx, nsmClient, conn, config.requestTimeout(index)); err != nil {
+		log.FromContext(ctx).WithField("id", id).Warnf("failed to close connection: %s", err.Error())
+	}
+	resp, err := requestConnection(ctx, signalCtx, config, index, id, config.resumePolicy(false),
+		monitorClient, nsmClient, settings)
+	if err != nil {
+		return err
+	}
+	connections.store(resp)
+	if config.MaxConnectionLifetime > 0 {
+		rotations.start(signalCtx, id, config.MaxConnectionLifetime)
+	}
+	return nil
+}
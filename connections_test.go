@@ -0,0 +1,158 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"google.golang.org/grpc"
+)
+
+// testNSMClient - NetworkServiceClient establishing every requested connection with the first preferred mechanism
+type testNSMClient struct {
+	mu       sync.Mutex
+	requests []*networkservice.NetworkServiceRequest
+	closed   []string
+}
+
+func (c *testNSMClient) Request(_ context.Context, request *networkservice.NetworkServiceRequest, _ ...grpc.CallOption) (*networkservice.Connection, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requests = append(c.requests, request.Clone())
+	conn := request.GetConnection().Clone()
+	conn.Mechanism = request.GetMechanismPreferences()[0].Clone()
+	return conn, nil
+}
+
+func (c *testNSMClient) Close(_ context.Context, conn *networkservice.Connection, _ ...grpc.CallOption) (*empty.Empty, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = append(c.closed, conn.GetId())
+	return &empty.Empty{}, nil
+}
+
+func testConfig(t *testing.T, services ...string) *Config {
+	config := &Config{
+		Name:          "nsc",
+		InterfaceName: "nsm",
+		ResumePolicy:  resumePolicyNever,
+	}
+	for _, service := range services {
+		u, err := url.Parse(service)
+		if err != nil {
+			t.Fatal(err)
+		}
+		config.NetworkServices = append(config.NetworkServices, *u)
+	}
+	return config
+}
+
+func TestRequestConnectionAgain_IdenticalServices(t *testing.T) {
+	ctx := context.Background()
+	config := testConfig(t, "kernel://my-service/nsm", "kernel://my-service/nsm")
+	nsmClient := &testNSMClient{}
+	settings := newServiceSettings()
+
+	connections, err := requestAll(ctx, ctx, config, "", resumePolicyNever, nil, nsmClient, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conns := connections.list()
+	if len(conns) != 2 {
+		t.Fatalf("expected 2 connections, got %d", len(conns))
+	}
+	if conns[0].GetId() == conns[1].GetId() {
+		t.Fatalf("expected distinct connection IDs, got %s twice", conns[0].GetId())
+	}
+	first := conns[0].GetMechanism().GetParameters()[common.InterfaceNameKey]
+	second := conns[1].GetMechanism().GetParameters()[common.InterfaceNameKey]
+	if first == second {
+		t.Fatalf("expected distinct interface names, got %s twice", first)
+	}
+	for i, conn := range conns {
+		if index, ok := connections.indexOf(conn.GetId()); !ok || index != i {
+			t.Fatalf("expected index %d of connection %s, got %d", i, conn.GetId(), index)
+		}
+	}
+
+	id := conns[1].GetId()
+	if err := requestConnectionAgain(ctx, ctx, config, id, nil, nsmClient, connections, settings, newRotations()); err != nil {
+		t.Fatal(err)
+	}
+	if len(nsmClient.closed) != 1 || nsmClient.closed[0] != id {
+		t.Fatalf("expected connection %s to be closed, got %v", id, nsmClient.closed)
+	}
+	again := nsmClient.requests[len(nsmClient.requests)-1]
+	if got := again.GetMechanismPreferences()[0].GetParameters()[common.InterfaceNameKey]; got != second {
+		t.Fatalf("expected connection %s to be requested again with interface %s, got %s", id, second, got)
+	}
+	if index, ok := connections.indexOf(id); !ok || index != 1 {
+		t.Fatalf("expected index 1 of connection %s, got %d", id, index)
+	}
+	if len(connections.list()) != 2 || connections.load(conns[0].GetId()) == nil {
+		t.Fatalf("expected both connections to be kept, got %v", connections.list())
+	}
+}
+
+func TestRotateConnection(t *testing.T) {
+	ctx := context.Background()
+	config := testConfig(t, "kernel://my-service/nsm")
+	config.MaxConnectionLifetime = time.Millisecond
+	nsmClient := &testNSMClient{}
+	settings := newServiceSettings()
+	rotations := newRotations()
+	defer rotations.stopAll()
+
+	connections, err := requestAll(ctx, ctx, config, "", resumePolicyNever, nil, nsmClient, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := connections.list()[0].GetId()
+	rotations.start(ctx, id, config.MaxConnectionLifetime)
+	if due := <-rotations.due(); due != id {
+		t.Fatalf("expected connection %s to be due, got %s", id, due)
+	}
+	rotateConnection(ctx, ctx, config, id, nil, nsmClient, connections, settings, rotations)
+	if len(nsmClient.closed) != 1 || len(nsmClient.requests) != 2 {
+		t.Fatalf("expected connection %s to be closed and requested again, got %v closed and %d requests", id,
+			nsmClient.closed, len(nsmClient.requests))
+	}
+	// Rotated again after another lifetime
+	if due := <-rotations.due(); due != id {
+		t.Fatalf("expected connection %s to be due again, got %s", id, due)
+	}
+
+	// Closed by the client meanwhile
+	connections.delete(id)
+	rotateConnection(ctx, ctx, config, id, nil, nsmClient, connections, settings, rotations)
+	if len(nsmClient.closed) != 1 || len(nsmClient.requests) != 2 {
+		t.Fatalf("expected the closed connection %s to be skipped, got %v closed and %d requests", id,
+			nsmClient.closed, len(nsmClient.requests))
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"context"
+	"sync"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/allconnected"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/interfacesfile"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/statusfile"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// discoveryFiles - the files announcing the connections of the client to the other containers of the pod, the
+// writers are nil if their file is not configured
+type discoveryFiles struct {
+	statusWriter     *statusfile.Writer
+	interfacesWriter *interfacesfile.Writer
+	allConnected     *allconnected.Notifier
+
+	once sync.Once
+}
+
+// removeOnShutdown - removes the files as soon as signalCtx is done, before the connections are closed: the graceful
+// close may outlast the termination grace period of the pod, and the deferred removal doesn't run if the client is
+// killed meanwhile
+func (f *discoveryFiles) removeOnShutdown(ctx, signalCtx context.Context) {
+	<-signalCtx.Done()
+	f.remove(ctx)
+}
+
+// remove - removes the files once, the later calls wait for the first one to complete
+func (f *discoveryFiles) remove(ctx context.Context) {
+	f.once.Do(func() {
+		if f.statusWriter != nil {
+			if err := f.statusWriter.Remove(); err != nil {
+				log.FromContext(ctx).Error(err.Error())
+			}
+		}
+		if f.interfacesWriter != nil {
+			if err := f.interfacesWriter.Remove(); err != nil {
+				log.FromContext(ctx).Error(err.Error())
+			}
+		}
+		if err := f.allConnected.Remove(); err != nil {
+			log.FromContext(ctx).Error(err.Error())
+		}
+		log.FromContext(ctx).Debug("discovery files are removed")
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/allconnected"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/interfacesfile"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/statusfile"
+)
+
+func TestDiscoveryFiles_RemovedOnSIGTERM(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	statusPath := filepath.Join(dir, "status.json")
+	interfacesPath := filepath.Join(dir, "interfaces.json")
+	allConnectedPath := filepath.Join(dir, "all-connected")
+
+	files := &discoveryFiles{
+		statusWriter:     statusfile.NewWriter(statusPath),
+		interfacesWriter: interfacesfile.NewWriter(interfacesPath),
+		allConnected:     allconnected.NewNotifier(allConnectedPath),
+	}
+	if err := files.statusWriter.Store(&statusfile.Connection{NetworkService: "my-service", ID: "nsc-0", Mechanism: "KERNEL"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := files.interfacesWriter.Store(&interfacesfile.Interface{NetworkService: "my-service", ConnectionID: "nsc-0", Mechanism: "KERNEL"}); err != nil {
+		t.Fatal(err)
+	}
+	files.allConnected.SetExpected(ctx, []*networkservice.Connection{{
+		Id:        "nsc-0",
+		Mechanism: &networkservice.Mechanism{Parameters: map[string]string{common.InterfaceNameKey: "nsm"}},
+	}}, 1)
+	for _, path := range []string{statusPath, interfacesPath, allConnectedPath} {
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("expected %s to be written: %s", path, err.Error())
+		}
+	}
+
+	signalCtx, cancelSignalCtx := notifyContext(ctx, true, true)
+	defer cancelSignalCtx()
+	removed := make(chan struct{})
+	go func() {
+		files.removeOnShutdown(ctx, signalCtx)
+		close(removed)
+	}()
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-removed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the discovery files are not removed on SIGTERM")
+	}
+	for _, path := range []string{statusPath, interfacesPath, allConnectedPath} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be removed, got %v", path, err)
+		}
+	}
+}
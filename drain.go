@@ -0,0 +1,103 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Drain states reported by the admin endpoints
+const (
+	drainStateActive   = "active"
+	drainStateDraining = "draining"
+	drainStateDrained  = "drained"
+	drainStateResuming = "resuming"
+)
+
+// drainRequestTimeout - timeout for the main loop to accept a drain or resume request, it may be busy requesting the
+// connections
+const drainRequestTimeout = 5 * time.Second
+
+type drainRequest struct {
+	drain   bool
+	started chan struct{}
+}
+
+// drainer - passes the drain and resume admin requests to the main loop, so they don't race with the reload of the
+// network services and the VPP restart
+type drainer struct {
+	requests chan *drainRequest
+
+	mu    sync.Mutex
+	state string
+}
+
+func newDrainer() *drainer {
+	return &drainer{
+		requests: make(chan *drainRequest),
+		state:    drainStateActive,
+	}
+}
+
+// Drain - implements admin.Drainer
+func (d *drainer) Drain(ctx context.Context) error {
+	return d.request(ctx, true)
+}
+
+// Resume - implements admin.Drainer
+func (d *drainer) Resume(ctx context.Context) error {
+	return d.request(ctx, false)
+}
+
+// State - implements admin.Drainer
+func (d *drainer) State() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.state
+}
+
+func (d *drainer) setState(state string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.state = state
+}
+
+func (d *drainer) request(ctx context.Context, drain bool) error {
+	ctx, cancel := context.WithTimeout(ctx, drainRequestTimeout)
+	defer cancel()
+
+	request := &drainRequest{
+		drain:   drain,
+		started: make(chan struct{}),
+	}
+	select {
+	case d.requests <- request:
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "client is busy, try again later")
+	}
+	<-request.started
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+type dryRunSummary struct {
+	ConnectTo string            `json:"connectTo"`
+	Requests  []json.RawMessage `json:"requests"`
+}
+
+// dryRun - prints the requests that would be sent to NSMgr
+func dryRun(config *Config, idSuffix string) error {
+	summary := &dryRunSummary{
+		ConnectTo: grpcutils.URLToTarget(&config.ConnectTo),
+	}
+	for i := range config.NetworkServices {
+		request := newRequest(config, i, config.serviceConnectionID(idSuffix, i))
+		data, err := protojson.Marshal(request)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal request for %s", config.NetworkServices[i].String())
+		}
+		summary.Requests = append(summary.Requests, data)
+	}
+
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal dry run summary")
+	}
+	fmt.Println(string(data))
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Exit codes of the process by the cause of the failure, so the orchestration can react to each differently. The
+// failures not classified, e.g. a timeout of the startup, exit with exitFailure.
+const (
+	exitOK      = 0
+	exitFailure = 1
+	// exitConfig - invalid configuration, e.g. an environment variable or a file it points to
+	exitConfig = 2
+	// exitVpp - VPP has failed to start, to restart or has died
+	exitVpp = 3
+	// exitAuth - the SVID or the credentials to talk to NSMgr can't be obtained
+	exitAuth = 4
+	// exitDial - NSMgr can't be resolved or dialed
+	exitDial = 5
+	// exitRequest - the request of a connection has failed
+	exitRequest = 6
+)
+
+// exitError - an error classified with the exit code of the process
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// withExitCode - classifies err with the exit code
+func withExitCode(code int, err error) error {
+	return &exitError{
+		code: code,
+		err:  err,
+	}
+}
+
+// exitCode - returns the exit code err is classified with, exitFailure if it is not classified
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	var e *exitError
+	if errors.As(err, &e) {
+		return e.code
+	}
+	return exitFailure
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// requestConnectionsAgain - closes each connection after the change of the watched interfaces and requests it again
+// from scratch, so the data plane is set up through the changed host networking. The connection is left in
+// connections if the request fails, so the heal or the watchdog can still recover it. Should be called from the same
+// goroutine as the requests of all connections.
+func requestConnectionsAgain(ctx, signalCtx context.Context, config *Config, changed []string,
+	monitorClient networkservice.MonitorConnectionClient, nsmClient networkservice.NetworkServiceClient,
+	connections *connectionStore, settings *serviceSettings, rotations *rotations) {
+	log.FromContext(ctx).Warnf("hostlink: %s changed, requesting all connections again", strings.Join(changed, ", "))
+
+	now := time.Now()
+	for _, conn := range connections.list() {
+		if err := requestConnectionAgain(ctx, signalCtx, config, conn.GetId(), monitorClient, nsmClient, connections,
+			settings, rotations); err != nil {
+			if signalCtx.Err() != nil {
+				log.FromContext(ctx).Info("hostlink: shutdown is requested while requesting connections again")
+				return
+			}
+			log.FromContext(ctx).WithField("id", conn.GetId()).Errorf("hostlink: failed to request connection again: %s", err.Error())
+		}
+	}
+	log.FromContext(ctx).WithField("duration", time.Since(now)).Info("hostlink: all connections are requested again")
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// closeIdleConnection - closes the connection with the id reported by the idle detector, it is not requested again
+// until the network services are reloaded or the connections are resumed. Should be called from the same goroutine as the requests of all
+// connections.
+func closeIdleConnection(ctx context.Context, config *Config, id string, nsmClient networkservice.NetworkServiceClient,
+	connections *connectionStore, settings *serviceSettings, rotations *rotations) {
+	conn := connections.load(id)
+	if conn == nil {
+		return
+	}
+
+	logger := log.FromContext(ctx).WithField("id", id)
+	logger.Infof("idle: connection to %s has had no traffic for %s, closing it", conn.GetNetworkService(), config.IdleTimeout)
+
+	timeout := config.RequestTimeout
+	if index, ok := connections.indexOf(id); ok && index < len(config.NetworkServices) {
+		timeout = config.requestTimeout(index)
+	}
+	rotations.stop(id)
+	if err := closeConnection(ctx, nsmClient, conn, timeout); err != nil {
+		logger.Warnf("idle: failed to close connection: %s", err.Error())
+	}
+	connections.deleteIdle(id)
+	settings.delete(id)
+}
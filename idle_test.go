@@ -0,0 +1,58 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestReconcileConnections_SkipsIdle(t *testing.T) {
+	ctx := context.Background()
+	config := testConfig(t, "kernel://service-1/nsm-1", "kernel://service-2/nsm-2")
+	nsmClient := &testNSMClient{}
+	settings := newServiceSettings()
+	rotations := newRotations()
+
+	connections, err := requestAll(ctx, ctx, config, "", resumePolicyNever, nil, nsmClient, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idleID := connections.list()[1].GetId()
+	closeIdleConnection(ctx, config, idleID, nsmClient, connections, settings, rotations)
+	if connections.load(idleID) != nil {
+		t.Fatalf("expected idle connection %s to be deleted", idleID)
+	}
+
+	requests := len(nsmClient.requests)
+	reconcileConnections(ctx, ctx, config, "", nil, nsmClient, connections, settings, rotations)
+	if len(nsmClient.requests) != requests {
+		t.Fatalf("expected idle connection %s not to be requested again", idleID)
+	}
+
+	connections.clearIdle()
+	reconcileConnections(ctx, ctx, config, "", nil, nsmClient, connections, settings, rotations)
+	if connections.load(idleID) == nil {
+		t.Fatalf("expected idle connection %s to be requested again after the reload", idleID)
+	}
+	if index, ok := connections.indexOf(idleID); !ok || index != 1 {
+		t.Fatalf("expected index 1 of connection %s, got %d", idleID, index)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Drainer - drains and resumes the connections of the client
+type Drainer interface {
+	// Drain - requests to close all connections and to stop requesting them until Resume, fails if the request is not
+	// accepted before ctx is done
+	Drain(ctx context.Context) error
+	// Resume - requests to request all connections again, fails if the request is not accepted before ctx is done
+	Resume(ctx context.Context) error
+	// State - returns the current drain state
+	State() string
+}
+
+// DrainHandler - returns a handler reporting the drain state on GET and draining the connections on POST. The drain
+// goes on in the background, so POST returns the state once it is started.
+func DrainHandler(drainer Drainer) http.Handler {
+	return drainStateHandler("drain", drainer, drainer.Drain)
+}
+
+// ResumeHandler - returns a handler reporting the drain state on GET and requesting the drained connections again on
+// POST
+func ResumeHandler(drainer Drainer) http.Handler {
+	return drainStateHandler("resume", drainer, drainer.Resume)
+}
+
+func drainStateHandler(name string, drainer Drainer, action func(ctx context.Context) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = fmt.Fprintln(w, drainer.State())
+		case http.MethodPost:
+			logrus.WithField("admin", name).Info("Requested")
+			if err := action(r.Context()); err != nil {
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = fmt.Fprintln(w, drainer.State())
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			http.Error(w, "method is not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// RequireToken - returns a handler passing the requests with the token in the bearer Authorization header to handler
+// and rejecting the others
+func RequireToken(token string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admin provides an HTTP server for the runtime administration endpoints
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Server - HTTP server for the admin endpoints
+type Server struct {
+	mux *http.ServeMux
+}
+
+// NewServer - creates a Server with no endpoints
+func NewServer() *Server {
+	return &Server{
+		mux: http.NewServeMux(),
+	}
+}
+
+// Handle - registers handler for the pattern
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// ListenAndServe - serves the endpoints on listenOn until ctx is done
+func (s *Server) ListenAndServe(ctx context.Context, listenOn string) {
+	log.FromContext(ctx).Infof("Admin endpoints are enabled. Listening on %s", listenOn)
+	server := &http.Server{
+		Addr:         listenOn,
+		Handler:      s.mux,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.FromContext(ctx).Errorf("Failed to start admin server: %s", err.Error())
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package allconnected provides a readiness signal of the client: a log line and an optional file emitted each time
+// all requested connections become up
+package allconnected
+
+import (
+	"context"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/connwatch"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/statusfile"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Connection - connection in the all connected file
+type Connection struct {
+	ID        string `json:"connectionId"`
+	Interface string `json:"interface,omitempty"`
+}
+
+// Connected - content of the all connected file
+type Connected struct {
+	Connections []*Connection `json:"connections"`
+}
+
+// Notifier - logs "all network services are connected" with the connection IDs and the interfaces each time all
+// expected connections become up: once they are requested and again after each heal bringing the last down connection
+// up. If the path is set, the file at it is written with the connections then and removed while they are not all up.
+type Notifier struct {
+	path string
+
+	mu         sync.Mutex
+	services   int
+	interfaces map[string]string
+	up         map[string]bool
+	connected  bool
+	removed    bool
+}
+
+// NewNotifier - creates a Notifier with no expected connections writing the file at path if it is not empty
+func NewNotifier(path string) *Notifier {
+	return &Notifier{
+		path:       path,
+		interfaces: make(map[string]string),
+		up:         make(map[string]bool),
+	}
+}
+
+// SetExpected - sets the connections established to the requested services, all services are connected if there is
+// a connection to each of them and all connections are up. The connections seen for the first time are considered up
+// until the monitor stream tells otherwise, since they have just been established.
+func (n *Notifier) SetExpected(ctx context.Context, conns []*networkservice.Connection, services int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.services = services
+	n.interfaces = make(map[string]string, len(conns))
+	for _, conn := range conns {
+		n.interfaces[conn.GetId()] = conn.GetMechanism().GetParameters()[common.InterfaceNameKey]
+		if _, ok := n.up[conn.GetId()]; !ok {
+			n.up[conn.GetId()] = true
+		}
+	}
+	n.update(ctx)
+}
+
+// Watch - follows the connections with the client connection ID starting with idPrefix through the monitor stream
+// until ctx is done. The stream is opened again if it fails.
+func (n *Notifier) Watch(ctx context.Context, monitorClient networkservice.MonitorConnectionClient, idPrefix string) {
+	connwatch.Watch(ctx, monitorClient, idPrefix, "all connected", n.observe)
+}
+
+// Remove - removes the file, it is not written anymore afterwards
+func (n *Notifier) Remove() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.removed = true
+	return n.remove()
+}
+
+func (n *Notifier) remove() error {
+	if n.path == "" {
+		return nil
+	}
+	if err := os.Remove(n.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove all connected file %s", n.path)
+	}
+	return nil
+}
+
+func (n *Notifier) observe(ctx context.Context, eventType networkservice.ConnectionEventType, id string, conn *networkservice.Connection) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	switch {
+	case eventType == networkservice.ConnectionEventType_DELETE:
+		n.up[id] = false
+	case conn.GetState() == networkservice.State_DOWN:
+		n.up[id] = false
+	case conn.GetState() == networkservice.State_UP:
+		n.up[id] = true
+	default:
+		return
+	}
+	n.update(ctx)
+}
+
+// update - emits the signal if all expected connections have just become up, removes the file if some of them is down
+func (n *Notifier) update(ctx context.Context) {
+	if n.removed {
+		return
+	}
+	connected := n.services > 0 && len(n.interfaces) == n.services
+	for id := range n.interfaces {
+		connected = connected && n.up[id]
+	}
+	if connected == n.connected {
+		return
+	}
+	n.connected = connected
+
+	if !connected {
+		if err := n.remove(); err != nil {
+			log.FromContext(ctx).Error(err.Error())
+		}
+		return
+	}
+
+	status := &Connected{}
+	for id, ifName := range n.interfaces {
+		status.Connections = append(status.Connections, &Connection{ID: id, Interface: ifName})
+	}
+	sort.Slice(status.Connections, func(i, j int) bool { return status.Connections[i].ID < status.Connections[j].ID })
+
+	ids := make([]string, 0, len(status.Connections))
+	interfaces := make([]string, 0, len(status.Connections))
+	for _, c := range status.Connections {
+		ids = append(ids, c.ID)
+		interfaces = append(interfaces, c.Interface)
+	}
+	log.FromContext(ctx).
+		WithField("count", len(ids)).
+		WithField("ids", ids).
+		WithField("interfaces", interfaces).
+		Info("all network services are connected")
+
+	if n.path == "" {
+		return
+	}
+	if err := statusfile.WriteJSON(n.path, status); err != nil {
+		log.FromContext(ctx).Error(err.Error())
+	}
+}
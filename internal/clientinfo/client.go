@@ -0,0 +1,75 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clientinfo provides a chain element adding the pod, node and cluster names of the client to the labels of
+// the connections, taking them from configurable environment variables
+package clientinfo
+
+import (
+	"context"
+	"os"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Labels of the client info
+const (
+	NodeNameLabel    = "nodeName"
+	PodNameLabel     = "podName"
+	ClusterNameLabel = "clusterName"
+)
+
+type clientInfoClient struct {
+	envs map[string]string
+}
+
+// NewClient - returns a client chain element setting each label of envs to the value of the environment variable
+// envs maps it to. Unset variables and the labels already set on the connection are skipped, like the sdk clientinfo
+// client does for the NODE_NAME, POD_NAME and CLUSTER_NAME variables.
+func NewClient(envs map[string]string) networkservice.NetworkServiceClient {
+	return &clientInfoClient{
+		envs: envs,
+	}
+}
+
+func (c *clientInfoClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	conn := request.GetConnection()
+	if conn.GetLabels() == nil {
+		conn.Labels = make(map[string]string)
+	}
+	for label, env := range c.envs {
+		value, ok := os.LookupEnv(env)
+		if !ok {
+			log.FromContext(ctx).Warnf("Environment variable %s is not set. Skipping.", env)
+			continue
+		}
+		if oldValue, ok := conn.GetLabels()[label]; ok {
+			log.FromContext(ctx).Warnf("The label %s was already assigned to %s. Skipping.", label, oldValue)
+			continue
+		}
+		conn.GetLabels()[label] = value
+	}
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (c *clientInfoClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
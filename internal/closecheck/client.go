@@ -0,0 +1,127 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package closecheck provides a chain element verifying the VPP state of a connection is cleaned up on Close
+package closecheck
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/statusfile"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/govpp/binapi/ip"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+type closeCheckClient struct {
+	vppConn api.Connection
+}
+
+// NewClient - returns a client chain element checking VPP after the Close of a connection: if its interface or the
+// routes through it are left behind, a warning is logged, so the leaks of the repeated connects and closes are seen.
+// Should be placed before the mechanism chain elements, so they have undone their changes when it checks.
+func NewClient(vppConn api.Connection) networkservice.NetworkServiceClient {
+	return &closeCheckClient{
+		vppConn: vppConn,
+	}
+}
+
+func (c *closeCheckClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (c *closeCheckClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	logger := log.FromContext(ctx).WithField("closeCheckClient", "Close")
+
+	// Loaded before Close deletes it
+	swIfIndex, ok := ifindex.Load(ctx, true)
+	var name string
+	if ok {
+		var err error
+		if name, err = statusfile.InterfaceName(ctx, c.vppConn, swIfIndex); err != nil {
+			logger.Warnf("failed to get interface name: %s", err.Error())
+		}
+	}
+
+	// The error is returned to the caller, which logs it
+	rv, err := next.Client(ctx).Close(ctx, conn, opts...)
+	if !ok || name == "" {
+		return rv, err
+	}
+
+	// The index may be taken by a new interface at once, so the name tells if it is the same one
+	left, checkErr := statusfile.InterfaceName(ctx, c.vppConn, swIfIndex)
+	if checkErr != nil {
+		logger.Warnf("failed to check interface %s is deleted: %s", name, checkErr.Error())
+		return rv, err
+	}
+	if left != name {
+		return rv, err
+	}
+	routes, checkErr := routesThrough(ctx, c.vppConn, swIfIndex)
+	if checkErr != nil {
+		logger.Warnf("failed to check routes through interface %s: %s", name, checkErr.Error())
+	}
+	logger.Warnf("interface %s (swIfIndex %d) is left in VPP after close, routes through it: [%s]", name, swIfIndex,
+		strings.Join(routes, ", "))
+	return rv, err
+}
+
+// routesThrough - returns the prefixes of the routes of the default IPv4 and IPv6 tables with a path through swIfIndex
+func routesThrough(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) ([]string, error) {
+	var prefixes []string
+	for _, isIP6 := range []bool{false, true} {
+		dc, err := ip.NewServiceClient(vppConn).IPRouteDump(ctx, &ip.IPRouteDump{
+			Table: ip.IPTable{IsIP6: isIP6},
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "vppapi IPRouteDump returned error")
+		}
+		for {
+			details, recvErr := dc.Recv()
+			if recvErr == io.EOF {
+				break
+			}
+			if recvErr != nil {
+				_ = dc.Close()
+				return nil, errors.Wrap(recvErr, "vppapi IPRouteDump returned error")
+			}
+			for _, path := range details.Route.Paths {
+				if interface_types.InterfaceIndex(path.SwIfIndex) == swIfIndex {
+					prefixes = append(prefixes, details.Route.Prefix.String())
+					break
+				}
+			}
+		}
+		_ = dc.Close()
+	}
+	return prefixes, nil
+}
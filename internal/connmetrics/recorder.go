@@ -0,0 +1,124 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package connmetrics provides OpenTelemetry metrics of the uptime and the heals of the client connections
+package connmetrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/connwatch"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/metrics"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+type connection struct {
+	nse string
+	// up - the connection is up since upSince
+	up       bool
+	upSince  time.Time
+	everUp   bool
+	attrsOpt metric.MeasurementOption
+}
+
+// Recorder - exports the nsc_connection_uptime_seconds gauge with the time each client connection has been
+// continuously up and the nsc_connection_heals counter of the heals of each connection: the transitions from down
+// to up and the changes of the NSE of the connection. Both are labeled by the network service, the connection ID and the
+// tenant of the connection if it has one.
+type Recorder struct {
+	heals    metric.Int64Counter
+	tenantOf func(connectionID string) string
+
+	mu    sync.Mutex
+	conns map[string]*connection
+}
+
+// NewRecorder - creates a Recorder and registers its metrics, should be called after OpenTelemetry is initialized.
+// tenantOf returns the tenant of the connection with the client connection ID, empty if it has none.
+func NewRecorder(tenantOf func(connectionID string) string) *Recorder {
+	r := &Recorder{
+		heals: metrics.Int64Counter("nsc_connection_heals",
+			"Number of heals of the client connections"),
+		tenantOf: tenantOf,
+		conns:    make(map[string]*connection),
+	}
+	metrics.Float64ObservableGauge("nsc_connection_uptime_seconds",
+		"Time the client connections have been continuously up", r.observeUptime)
+	return r
+}
+
+// Watch - follows the connections with the client connection ID starting with idPrefix through the monitor stream
+// until ctx is done. The stream is opened again if it fails.
+func (r *Recorder) Watch(ctx context.Context, monitorClient networkservice.MonitorConnectionClient, idPrefix string) {
+	connwatch.Watch(ctx, monitorClient, idPrefix, "connection metrics", r.observe)
+}
+
+func (r *Recorder) observe(ctx context.Context, eventType networkservice.ConnectionEventType, id string, conn *networkservice.Connection) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if eventType == networkservice.ConnectionEventType_DELETE {
+		delete(r.conns, id)
+		return
+	}
+	c, ok := r.conns[id]
+	if !ok {
+		attrs := []attribute.KeyValue{
+			metrics.NetworkServiceKey.String(conn.GetNetworkService()),
+			metrics.ConnectionIDKey.String(id),
+		}
+		if tenant := r.tenantOf(id); tenant != "" {
+			attrs = append(attrs, metrics.TenantKey.String(tenant))
+		}
+		c = &connection{
+			attrsOpt: metric.WithAttributes(attrs...),
+		}
+		r.conns[id] = c
+	}
+	nse := conn.GetNetworkServiceEndpointName()
+
+	switch conn.GetState() {
+	case networkservice.State_DOWN:
+		c.up = false
+	case networkservice.State_UP:
+		if c.up && c.nse == nse {
+			break
+		}
+		if c.everUp {
+			r.heals.Add(ctx, 1, c.attrsOpt)
+		}
+		c.up, c.everUp, c.upSince = true, true, time.Now()
+	}
+	c.nse = nse
+}
+
+func (r *Recorder) observeUptime(_ context.Context, observer metric.Float64Observer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, c := range r.conns {
+		if c.up {
+			observer.Observe(time.Since(c.upSince).Seconds(), c.attrsOpt)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connwatch
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// InitialState - returns the connections known to NSMgr by their ID. NSMgr may send the initial state in several
+// events and repeat the connections, so the events are received until none arrives in settle or ctx is done, the
+// connections of INITIAL_STATE_TRANSFER and UPDATE events are merged and the ones of DELETE events are removed.
+// ctx must be the context of the stream, so that receiving stops once it is done.
+func InitialState(ctx context.Context, stream networkservice.MonitorConnection_MonitorConnectionsClient,
+	settle time.Duration) (map[string]*networkservice.Connection, error) {
+	events := make(chan *networkservice.ConnectionEvent)
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	connections := make(map[string]*networkservice.Connection)
+	select {
+	case event := <-events:
+		merge(connections, event)
+	case err := <-errCh:
+		return nil, err
+	case <-ctx.Done():
+		return nil, errors.Wrap(ctx.Err(), "no initial state is received")
+	}
+
+	timer := time.NewTimer(settle)
+	defer timer.Stop()
+	for {
+		select {
+		case event := <-events:
+			merge(connections, event)
+			timer.Reset(settle)
+		case <-errCh:
+			// the initial state is received already, so the connections are still useful
+			return connections, nil
+		case <-timer.C:
+			return connections, nil
+		case <-ctx.Done():
+			return connections, nil
+		}
+	}
+}
+
+func merge(connections map[string]*networkservice.Connection, event *networkservice.ConnectionEvent) {
+	for id, conn := range event.GetConnections() {
+		if conn.GetId() != "" {
+			id = conn.GetId()
+		}
+		if event.GetType() == networkservice.ConnectionEventType_DELETE {
+			delete(connections, id)
+			continue
+		}
+		connections[id] = conn
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package connwatch provides following the client connections through the NSMgr monitor stream
+package connwatch
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const watchRetryInterval = time.Second
+
+// ObserveFunc - is called for each client connection in each monitor event, id is the client connection ID
+type ObserveFunc func(ctx context.Context, eventType networkservice.ConnectionEventType, id string, conn *networkservice.Connection)
+
+// Watch - calls observe for the connections with the client connection ID starting with idPrefix in the events of the
+// monitor stream until ctx is done. The stream is opened again if it fails, name is used in the log.
+func Watch(ctx context.Context, monitorClient networkservice.MonitorConnectionClient, idPrefix, name string, observe ObserveFunc) {
+	for ctx.Err() == nil {
+		if err := watch(ctx, monitorClient, idPrefix, observe); err != nil && ctx.Err() == nil {
+			log.FromContext(ctx).Warnf("%s stream has failed, opening it again: %s", name, err.Error())
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(watchRetryInterval):
+		}
+	}
+}
+
+func watch(ctx context.Context, monitorClient networkservice.MonitorConnectionClient, idPrefix string, observe ObserveFunc) error {
+	stream, err := monitorClient.MonitorConnections(ctx, &networkservice.MonitorScopeSelector{})
+	if err != nil {
+		return err
+	}
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		for _, conn := range event.GetConnections() {
+			segments := conn.GetPath().GetPathSegments()
+			if len(segments) == 0 || !strings.HasPrefix(segments[0].GetId(), idPrefix) {
+				continue
+			}
+			observe(ctx, event.GetType(), segments[0].GetId(), conn)
+		}
+	}
+}
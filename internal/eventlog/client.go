@@ -0,0 +1,52 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package eventlog
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type eventLogClient struct {
+	logger *Logger
+}
+
+// NewClient - returns a client chain element logging the requests and the closes of the connections to logger. The
+// other transitions are logged by Logger.Watch from the monitor stream.
+func NewClient(logger *Logger) networkservice.NetworkServiceClient {
+	return &eventLogClient{
+		logger: logger,
+	}
+}
+
+func (c *eventLogClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	c.logger.requested(ctx, request.GetConnection())
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (c *eventLogClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.logger.closed(ctx, conn)
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package eventlog provides an audit trail of the state transitions of the client connections in the log
+package eventlog
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/connwatch"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Connection events
+const (
+	Requested = "requested"
+	Up        = "up"
+	Healed    = "healed"
+	Down      = "down"
+	Closed    = "closed"
+)
+
+type state struct {
+	event string
+	nse   string
+}
+
+// Logger - logs each transition of the client connections at INFO level as "connection event" with the event, the
+// connection ID, the network service, the mechanism and the timestamp fields
+type Logger struct {
+	mu     sync.Mutex
+	states map[string]*state
+}
+
+// NewLogger - creates a Logger with no known connections
+func NewLogger() *Logger {
+	return &Logger{
+		states: make(map[string]*state),
+	}
+}
+
+// Watch - follows the connections with the client connection ID starting with idPrefix through the monitor stream
+// until ctx is done and logs their up, healed and down transitions. The stream is opened again if it fails.
+func (l *Logger) Watch(ctx context.Context, monitorClient networkservice.MonitorConnectionClient, idPrefix string) {
+	connwatch.Watch(ctx, monitorClient, idPrefix, "connection event", l.observe)
+}
+
+// observe - logs the transition of the connection with the client id to the state in the monitor event
+func (l *Logger) observe(ctx context.Context, eventType networkservice.ConnectionEventType, id string, conn *networkservice.Connection) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	prev, ok := l.states[id]
+	if !ok {
+		prev = new(state)
+		l.states[id] = prev
+	}
+	nse := conn.GetNetworkServiceEndpointName()
+
+	var event string
+	switch {
+	case eventType == networkservice.ConnectionEventType_DELETE:
+		if prev.event != Closed {
+			event = Down
+		}
+		delete(l.states, id)
+	case prev.event == Closed:
+		// The updates sent by NSMgr before it deletes the connection closed by the client
+		return
+	case conn.GetState() == networkservice.State_DOWN:
+		if prev.event != Down {
+			event = Down
+		}
+	case conn.GetState() != networkservice.State_UP:
+		return
+	case prev.event == Down, (prev.event == Up || prev.event == Healed) && prev.nse != nse:
+		event = Healed
+	case prev.event == "", prev.event == Requested:
+		event = Up
+	}
+	prev.nse = nse
+	if event == "" {
+		return
+	}
+	if eventType != networkservice.ConnectionEventType_DELETE {
+		prev.event = event
+	}
+	l.log(ctx, event, id, conn)
+}
+
+// requested - logs the request of the connection, unless it is a refresh of the connection which is up
+func (l *Logger) requested(ctx context.Context, conn *networkservice.Connection) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	prev, ok := l.states[conn.GetId()]
+	if !ok {
+		prev = new(state)
+		l.states[conn.GetId()] = prev
+	}
+	if prev.event == Up || prev.event == Healed || prev.event == Requested {
+		return
+	}
+	prev.event = Requested
+	l.log(ctx, Requested, conn.GetId(), conn)
+}
+
+// closed - logs the close of the connection by the client
+func (l *Logger) closed(ctx context.Context, conn *networkservice.Connection) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.states[conn.GetId()] = &state{event: Closed}
+	l.log(ctx, Closed, conn.GetId(), conn)
+}
+
+func (l *Logger) log(ctx context.Context, event, id string, conn *networkservice.Connection) {
+	log.FromContext(ctx).
+		WithField("event", event).
+		WithField("id", id).
+		WithField("service", conn.GetNetworkService()).
+		WithField("mechanism", conn.GetMechanism().GetType()).
+		WithField("timestamp", time.Now().Format(time.RFC3339Nano)).
+		Info("connection event")
+}
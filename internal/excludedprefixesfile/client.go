@@ -0,0 +1,122 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package excludedprefixesfile provides a client chain element adding excluded prefixes read from a file to the
+// requests. The file is watched, so the changes are applied on the next Request or refresh without a restart.
+package excludedprefixesfile
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/fs"
+	"github.com/networkservicemesh/sdk/pkg/tools/ippool"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type excludedPrefixesFileClient struct {
+	prefixes atomic.Value
+}
+
+// NewClient - returns a client chain element adding excluded prefixes from the file at configPath to the requests.
+// The file has the same format as the NSM excluded prefixes config map:
+//
+//	prefixes:
+//	- 10.96.0.0/12
+//	- 10.244.0.0/16
+func NewClient(ctx context.Context, configPath string) networkservice.NetworkServiceClient {
+	c := &excludedPrefixesFileClient{}
+	c.prefixes.Store([]string(nil))
+
+	logger := log.FromContext(ctx).WithField("excludedprefixesfile", configPath)
+	updatePrefixes := func(bytes []byte) {
+		source := struct {
+			Prefixes []string
+		}{}
+		if err := yaml.Unmarshal(bytes, &source); err != nil {
+			logger.Errorf("can not unmarshal prefixes, err: %v", err.Error())
+			return
+		}
+		pool, err := ippool.NewPool(source.Prefixes...)
+		if err != nil {
+			logger.Errorf("can not create prefix pool with prefixes: %+v, err: %v", source.Prefixes, err.Error())
+			return
+		}
+		logger.Infof("excluded prefixes are updated: %v", pool.GetPrefixes())
+		c.prefixes.Store(pool.GetPrefixes())
+	}
+
+	updateCh := fs.WatchFile(ctx, configPath)
+	updatePrefixes(<-updateCh)
+	go func() {
+		for update := range updateCh {
+			updatePrefixes(update)
+		}
+	}()
+
+	return c
+}
+
+func (c *excludedPrefixesFileClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	conn := request.GetConnection()
+	if conn.GetContext() == nil {
+		conn.Context = &networkservice.ConnectionContext{}
+	}
+	if conn.GetContext().GetIpContext() == nil {
+		conn.Context.IpContext = &networkservice.IPContext{}
+	}
+	ipCtx := conn.GetContext().GetIpContext()
+
+	// Prefixes added on the previous Request could be removed from the file since then
+	previous, _ := load(ctx)
+	oldExcludedPrefixes := ipCtx.GetExcludedPrefixes()
+	base := exclude(oldExcludedPrefixes, previous)
+	added := exclude(c.prefixes.Load().([]string), base)
+	ipCtx.ExcludedPrefixes = append(base, added...)
+
+	resp, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		ipCtx.ExcludedPrefixes = oldExcludedPrefixes
+		return nil, err
+	}
+	store(ctx, added)
+	return resp, nil
+}
+
+func (c *excludedPrefixesFileClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+// exclude - returns source without the elements from excluded
+func exclude(source, excluded []string) []string {
+	excludedSet := make(map[string]struct{}, len(excluded))
+	for _, s := range excluded {
+		excludedSet[s] = struct{}{}
+	}
+	var result []string
+	for _, s := range source {
+		if _, ok := excludedSet[s]; !ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
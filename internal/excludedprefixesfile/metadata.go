@@ -0,0 +1,40 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package excludedprefixesfile
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type key struct{}
+
+// store sets the excluded prefixes added from the file, stored in per Connection.Id metadata
+func store(ctx context.Context, prefixes []string) {
+	metadata.Map(ctx, true).Store(key{}, prefixes)
+}
+
+// load returns the excluded prefixes added from the file, stored in per Connection.Id metadata
+func load(ctx context.Context) (value []string, ok bool) {
+	rawValue, ok := metadata.Map(ctx, true).Load(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.([]string)
+	return value, ok
+}
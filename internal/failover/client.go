@@ -0,0 +1,88 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failover
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+type failoverClient struct {
+	selector *Selector
+	clients  []networkservice.NetworkServiceClient
+
+	mu          sync.Mutex
+	connClients map[string]int
+}
+
+// NewClient - returns a client sending the requests to the client of the NSMgr currently used by selector, clients
+// should go in the order of the selector URLs. The requests failed to reach the NSMgr, with codes.Unavailable or while
+// the monitor connection to it is in the failed state, make the selector switch to the next NSMgr after several in a
+// row, so the retries go there. The other failures, e.g. of the NSE, don't count. Closes are sent to the client the
+// connection has been established with.
+func NewClient(selector *Selector, clients ...networkservice.NetworkServiceClient) networkservice.NetworkServiceClient {
+	return &failoverClient{
+		selector:    selector,
+		clients:     clients,
+		connClients: make(map[string]int),
+	}
+}
+
+func (c *failoverClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	idx := c.selector.Current()
+	conn, err := c.clients[idx].Request(ctx, request, opts...)
+	if err != nil {
+		if status.Code(err) == codes.Unavailable || c.selector.Unreachable(idx) {
+			c.selector.RequestFailed(ctx, idx)
+		}
+		return nil, err
+	}
+	c.selector.RequestSucceeded(idx)
+
+	c.mu.Lock()
+	c.connClients[conn.GetId()] = idx
+	c.mu.Unlock()
+
+	return conn, nil
+}
+
+func (c *failoverClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.mu.Lock()
+	idx, ok := c.connClients[conn.GetId()]
+	c.mu.Unlock()
+
+	if !ok {
+		idx = c.selector.Current()
+	}
+	resp, err := c.clients[idx].Close(ctx, conn, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	delete(c.connClients, conn.GetId())
+	c.mu.Unlock()
+
+	return resp, nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failover_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/failover"
+)
+
+type errorClient struct {
+	err error
+}
+
+func (c *errorClient) Request(context.Context, *networkservice.NetworkServiceRequest, ...grpc.CallOption) (*networkservice.Connection, error) {
+	return nil, c.err
+}
+
+func (c *errorClient) Close(context.Context, *networkservice.Connection, ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func newSelector() *failover.Selector {
+	return failover.NewSelector(&url.URL{Scheme: "unix", Path: "/nsmgr-1.sock"}, &url.URL{Scheme: "unix", Path: "/nsmgr-2.sock"})
+}
+
+func request(client networkservice.NetworkServiceClient, times int) {
+	for i := 0; i < times; i++ {
+		_, _ = client.Request(context.Background(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{Id: "nsc-0"},
+		})
+	}
+}
+
+func TestFailoverClient_Unavailable(t *testing.T) {
+	selector := newSelector()
+	client := failover.NewClient(selector,
+		&errorClient{err: errors.Wrap(status.Error(codes.Unavailable, "connection refused"), "request has failed")},
+		&errorClient{})
+
+	request(client, 2)
+	if selector.Current() != 0 {
+		t.Fatalf("expected no failover before the threshold, got NSMgr %d", selector.Current())
+	}
+	request(client, 1)
+	if selector.Current() != 1 {
+		t.Fatalf("expected failover to NSMgr 1, got NSMgr %d", selector.Current())
+	}
+	select {
+	case <-selector.Switches():
+	default:
+		t.Fatal("expected the switch to be notified")
+	}
+}
+
+func TestFailoverClient_OtherErrors(t *testing.T) {
+	selector := newSelector()
+	client := failover.NewClient(selector,
+		&errorClient{err: status.Error(codes.NotFound, "network service is not found")},
+		&errorClient{})
+
+	request(client, 10)
+	if selector.Current() != 0 {
+		t.Fatalf("expected no failover on the errors of the NSE, got NSMgr %d", selector.Current())
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package failover provides switching between several NSMgrs when the current one fails
+package failover
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// failureThreshold - number of the consecutive failed requests to the current NSMgr after which the next one is used
+const failureThreshold = 3
+
+// Selector - keeps track of the NSMgr currently used out of several ones and of the monitor connection to it
+type Selector struct {
+	urls     []*url.URL
+	switches chan struct{}
+
+	mu       sync.Mutex
+	current  int
+	failures int
+	cc       *grpc.ClientConn
+	ccIndex  int
+}
+
+// NewSelector - creates a Selector starting with the first of urls, the rest are used in order on failures
+func NewSelector(urls ...*url.URL) *Selector {
+	return &Selector{
+		urls:     urls,
+		switches: make(chan struct{}, 1),
+	}
+}
+
+// URLs - returns the NSMgr URLs in order
+func (s *Selector) URLs() []*url.URL {
+	return s.urls
+}
+
+// Current - returns the index of the NSMgr currently used
+func (s *Selector) Current() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.current
+}
+
+// Switches - returns a channel receiving a value when the requests fail over to another NSMgr, so the monitor
+// connection is dialed again with Redial
+func (s *Selector) Switches() <-chan struct{} {
+	return s.switches
+}
+
+// Failover - switches to the next NSMgr if the failed one is still the current one, the switch is not notified, e.g.
+// while the monitor connection is dialed
+func (s *Selector) Failover(ctx context.Context, failed int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failoverLocked(ctx, failed)
+}
+
+func (s *Selector) failoverLocked(ctx context.Context, failed int) bool {
+	if len(s.urls) == 1 || failed != s.current {
+		return false
+	}
+	s.current = (s.current + 1) % len(s.urls)
+	s.failures = 0
+	log.FromContext(ctx).Warnf("NSMgr %s has failed, failing over to NSMgr %s", s.urls[failed].String(), s.urls[s.current].String())
+	return true
+}
+
+// RequestFailed - counts a request failed to reach the failed NSMgr, switches to the next NSMgr after
+// failureThreshold consecutive failures if the failed one is still the current one
+func (s *Selector) RequestFailed(ctx context.Context, failed int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if failed != s.current {
+		return
+	}
+	s.failures++
+	if s.failures < failureThreshold || !s.failoverLocked(ctx, failed) {
+		return
+	}
+	select {
+	case s.switches <- struct{}{}:
+	default:
+	}
+}
+
+// RequestSucceeded - resets the count of the failed requests if the succeeded NSMgr is the current one
+func (s *Selector) RequestSucceeded(succeeded int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if succeeded == s.current {
+		s.failures = 0
+	}
+}
+
+// Unreachable - returns true if the monitor connection to the NSMgr with the index is in the failed state
+func (s *Selector) Unreachable(index int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.cc != nil && s.ccIndex == index && s.cc.GetState() == connectivity.TransientFailure
+}
+
+// Dial - dials the current NSMgr, on failure each of the other ones is tried in order with timeout.
+// If there is only one NSMgr, it is dialed without waiting for the connection to be established.
+func (s *Selector) Dial(ctx context.Context, timeout time.Duration, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	if len(s.urls) == 1 {
+		dialCtx, cancelDial := context.WithTimeout(ctx, timeout)
+		defer cancelDial()
+
+		cc, err := grpc.DialContext(dialCtx, grpcutils.URLToTarget(s.urls[0]), opts...)
+		if err == nil {
+			s.setConn(cc, 0)
+		}
+		return cc, err
+	}
+
+	opts = append(opts[:len(opts):len(opts)], grpc.WithBlock())
+	for range s.urls {
+		idx := s.Current()
+		dialCtx, cancelDial := context.WithTimeout(ctx, timeout)
+		cc, err := grpc.DialContext(dialCtx, grpcutils.URLToTarget(s.urls[idx]), opts...)
+		cancelDial()
+		if err == nil {
+			s.setConn(cc, idx)
+			return cc, nil
+		}
+		log.FromContext(ctx).Errorf("failed to dial NSMgr %s: %s", s.urls[idx].String(), err.Error())
+		s.Failover(ctx, idx)
+	}
+	return nil, errors.Errorf("failed to dial any of %d NSMgrs", len(s.urls))
+}
+
+// Redial - closes the monitor connection and dials the current NSMgr again without waiting for the connection to be
+// established, e.g. after the requests have failed over to it
+func (s *Selector) Redial(ctx context.Context, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	idx := s.Current()
+	cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(s.urls[idx]), opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial NSMgr %s", s.urls[idx].String())
+	}
+	s.setConn(cc, idx)
+	return cc, nil
+}
+
+func (s *Selector) setConn(cc *grpc.ClientConn, index int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cc != nil && s.cc != cc {
+		_ = s.cc.Close()
+	}
+	s.cc, s.ccIndex = cc, index
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package healthlog provides a periodic log of the state, the uptime and the interface counters of the client
+// connections
+package healthlog
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/connwatch"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/ifstats"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type connection struct {
+	service string
+	nse     string
+	up      bool
+	// since - the connection is up or down since then
+	since time.Time
+}
+
+// Logger - logs a summary of the client connections periodically: the state of each of them, the time it has been in
+// the state and the counters of its interface if they are collected
+type Logger struct {
+	collector *ifstats.Collector
+
+	mu    sync.Mutex
+	conns map[string]*connection
+}
+
+// NewLogger - creates a Logger taking the interface counters from collector, the counters are not logged if it is nil
+func NewLogger(collector *ifstats.Collector) *Logger {
+	return &Logger{
+		collector: collector,
+		conns:     make(map[string]*connection),
+	}
+}
+
+// Watch - follows the connections with the client connection ID starting with idPrefix through the monitor stream
+// until ctx is done. The stream is opened again if it fails.
+func (l *Logger) Watch(ctx context.Context, monitorClient networkservice.MonitorConnectionClient, idPrefix string) {
+	connwatch.Watch(ctx, monitorClient, idPrefix, "health log", l.observe)
+}
+
+// Run - logs the summary each interval until ctx is done
+func (l *Logger) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.log(ctx)
+		}
+	}
+}
+
+func (l *Logger) observe(_ context.Context, eventType networkservice.ConnectionEventType, id string, conn *networkservice.Connection) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if eventType == networkservice.ConnectionEventType_DELETE {
+		delete(l.conns, id)
+		return
+	}
+	up := conn.GetState() == networkservice.State_UP
+	c, ok := l.conns[id]
+	if !ok || c.up != up {
+		c = &connection{up: up, since: time.Now()}
+		l.conns[id] = c
+	}
+	c.service = conn.GetNetworkService()
+	c.nse = conn.GetNetworkServiceEndpointName()
+}
+
+func (l *Logger) log(ctx context.Context) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ids := make([]string, 0, len(l.conns))
+	upCount := 0
+	for id, c := range l.conns {
+		ids = append(ids, id)
+		if c.up {
+			upCount++
+		}
+	}
+	sort.Strings(ids)
+
+	var stats map[string]ifstats.Stats
+	if l.collector != nil {
+		stats = l.collector.List()
+	}
+	log.FromContext(ctx).Infof("connection health: %d of %d connections are up", upCount, len(ids))
+	for _, id := range ids {
+		c := l.conns[id]
+		state := "down"
+		if c.up {
+			state = "up"
+		}
+		logger := log.FromContext(ctx).
+			WithField("id", id).
+			WithField("service", c.service).
+			WithField("nse", c.nse).
+			WithField("state", state).
+			WithField("for", time.Since(c.since).Round(time.Second))
+		if s, ok := stats[id]; ok {
+			logger = logger.
+				WithField("interface", s.InterfaceName).
+				WithField("rx_packets", s.RxPackets).
+				WithField("rx_bytes", s.RxBytes).
+				WithField("tx_packets", s.TxPackets).
+				WithField("tx_bytes", s.TxBytes).
+				WithField("drops", s.Drops)
+		}
+		logger.Info("connection health")
+	}
+}
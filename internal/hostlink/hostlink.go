@@ -0,0 +1,254 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package hostlink provides a watcher of the link state of the interfaces in the client network namespace, e.g. eth0
+// set up by the CNI
+package hostlink
+
+import (
+	"context"
+	"encoding/binary"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	// pollInterval - how long a read of the netlink socket blocks, so ctx is checked
+	pollInterval = 500 * time.Millisecond
+	// stateFlags - the flags of the link state, the others don't affect the connections
+	stateFlags = unix.IFF_UP | unix.IFF_RUNNING | unix.IFF_LOWER_UP
+	// ifInfoIndexOffset, ifInfoFlagsOffset - offsets of ifi_index and ifi_flags in struct ifinfomsg
+	ifInfoIndexOffset = 4
+	ifInfoFlagsOffset = 8
+)
+
+type state struct {
+	index uint32
+	flags uint32
+}
+
+// Watcher - reports the names of the watched interfaces which are created, deleted or which link state changes.
+// The changes are reported together once no other change follows for the settle time, as the CNI often makes several.
+type Watcher struct {
+	names   map[string]bool
+	settle  time.Duration
+	changes chan []string
+
+	fd     int
+	states map[string]state
+}
+
+// NewWatcher - subscribes to the link changes of the network namespace and records the current state of the names
+func NewWatcher(names []string, settle time.Duration) (*Watcher, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_ROUTE)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open netlink socket")
+	}
+	if err = unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: unix.RTMGRP_LINK}); err != nil {
+		_ = unix.Close(fd)
+		return nil, errors.Wrap(err, "failed to subscribe to link changes")
+	}
+	timeout := unix.NsecToTimeval(pollInterval.Nanoseconds())
+	if err = unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &timeout); err != nil {
+		_ = unix.Close(fd)
+		return nil, errors.Wrap(err, "failed to set netlink socket timeout")
+	}
+
+	w := &Watcher{
+		names:   make(map[string]bool),
+		settle:  settle,
+		changes: make(chan []string),
+		fd:      fd,
+	}
+	for _, name := range names {
+		w.names[name] = true
+	}
+	// Dumped after the subscription, so no change is missed in between
+	if w.states, err = w.dump(); err != nil {
+		_ = unix.Close(fd)
+		return nil, err
+	}
+	return w, nil
+}
+
+// Changes - returns the channel the names of the changed interfaces are sent to
+func (w *Watcher) Changes() <-chan []string {
+	return w.changes
+}
+
+// Watch - reads the link changes until ctx is done, closes the netlink socket then
+func (w *Watcher) Watch(ctx context.Context) {
+	defer func() { _ = unix.Close(w.fd) }()
+
+	logger := log.FromContext(ctx).WithField("hostlink", "Watch")
+	for name := range w.names {
+		if st, ok := w.states[name]; ok {
+			logger.Infof("watching %s, up: %t", name, st.flags&unix.IFF_RUNNING != 0)
+		} else {
+			logger.Infof("watching %s, it doesn't exist yet", name)
+		}
+	}
+
+	buf := make([]byte, unix.Getpagesize())
+	pending := make(map[string]bool)
+	var lastChange time.Time
+	for ctx.Err() == nil {
+		if len(pending) > 0 && time.Since(lastChange) >= w.settle {
+			if !w.send(ctx, pending) {
+				return
+			}
+			pending = make(map[string]bool)
+		}
+
+		n, _, err := unix.Recvfrom(w.fd, buf, 0)
+		var changed []string
+		switch {
+		case err == unix.EAGAIN || err == unix.EINTR:
+			continue
+		case err == unix.ENOBUFS:
+			// Some changes are lost, so the current state is compared with the recorded one
+			logger.Warn("netlink socket overflowed, dumping the links again")
+			changed, err = w.resync()
+		case err == nil:
+			changed, err = w.handle(buf[:n])
+		}
+		if err != nil {
+			logger.Errorf("failed to read link changes: %s", err.Error())
+			return
+		}
+		for _, name := range changed {
+			logger.Infof("link state of %s has changed", name)
+			pending[name] = true
+			lastChange = time.Now()
+		}
+	}
+}
+
+func (w *Watcher) send(ctx context.Context, pending map[string]bool) bool {
+	names := make([]string, 0, len(pending))
+	for name := range pending {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	select {
+	case <-ctx.Done():
+		return false
+	case w.changes <- names:
+		return true
+	}
+}
+
+func (w *Watcher) handle(data []byte) ([]string, error) {
+	msgs, err := syscall.ParseNetlinkMessage(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse netlink message")
+	}
+	var changed []string
+	for i := range msgs {
+		name, st, ok := parseLink(&msgs[i])
+		if !ok || !w.names[name] {
+			continue
+		}
+		prev, known := w.states[name]
+		switch {
+		case msgs[i].Header.Type == unix.RTM_DELLINK:
+			if !known {
+				continue
+			}
+			delete(w.states, name)
+		case !known || prev != st:
+			w.states[name] = st
+		default:
+			continue
+		}
+		changed = append(changed, name)
+	}
+	return changed, nil
+}
+
+func (w *Watcher) resync() ([]string, error) {
+	states, err := w.dump()
+	if err != nil {
+		return nil, err
+	}
+	var changed []string
+	for name := range w.names {
+		prev, wasKnown := w.states[name]
+		st, known := states[name]
+		if wasKnown != known || prev != st {
+			changed = append(changed, name)
+		}
+	}
+	w.states = states
+	return changed, nil
+}
+
+// dump - returns the state of the watched interfaces which exist
+func (w *Watcher) dump() (map[string]state, error) {
+	data, err := syscall.NetlinkRIB(unix.RTM_GETLINK, unix.AF_UNSPEC)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to dump links")
+	}
+	msgs, err := syscall.ParseNetlinkMessage(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse links")
+	}
+	states := make(map[string]state)
+	for i := range msgs {
+		if name, st, ok := parseLink(&msgs[i]); ok && w.names[name] {
+			states[name] = st
+		}
+	}
+	return states, nil
+}
+
+func parseLink(msg *syscall.NetlinkMessage) (name string, st state, ok bool) {
+	if msg.Header.Type != unix.RTM_NEWLINK && msg.Header.Type != unix.RTM_DELLINK || len(msg.Data) < unix.SizeofIfInfomsg {
+		return "", state{}, false
+	}
+	attrs, err := syscall.ParseNetlinkRouteAttr(msg)
+	if err != nil {
+		return "", state{}, false
+	}
+	for _, attr := range attrs {
+		if attr.Attr.Type == unix.IFLA_IFNAME {
+			name = string(trimNull(attr.Value))
+		}
+	}
+	st = state{
+		index: binary.NativeEndian.Uint32(msg.Data[ifInfoIndexOffset:]),
+		flags: binary.NativeEndian.Uint32(msg.Data[ifInfoFlagsOffset:]) & stateFlags,
+	}
+	return name, st, name != ""
+}
+
+func trimNull(value []byte) []byte {
+	for i, b := range value {
+		if b == 0 {
+			return value[:i]
+		}
+	}
+	return value
+}
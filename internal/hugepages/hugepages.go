@@ -0,0 +1,86 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hugepages provides the hugepage configuration of the node VPP allocates its memory from
+package hugepages
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MeminfoPath - path of the kernel memory info, it reports the hugepages of the whole node
+const MeminfoPath = "/proc/meminfo"
+
+// Info - hugepages of the default size
+type Info struct {
+	Total uint64
+	Free  uint64
+	// PageSize - size of a hugepage in bytes
+	PageSize uint64
+}
+
+// Read - returns the hugepages reported in the memory info file at path
+func Read(path string) (*Info, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s", path)
+	}
+	defer func() { _ = file.Close() }()
+
+	info := &Info{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// e.g. "HugePages_Total:    1024" or "Hugepagesize:       2048 kB"
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		var target *uint64
+		multiplier := uint64(1)
+		switch key {
+		case "HugePages_Total":
+			target = &info.Total
+		case "HugePages_Free":
+			target = &info.Free
+		case "Hugepagesize":
+			target = &info.PageSize
+			if len(fields) > 1 && fields[1] == "kB" {
+				multiplier = 1024
+			}
+		default:
+			continue
+		}
+		n, parseErr := strconv.ParseUint(fields[0], 10, 64)
+		if parseErr != nil {
+			return nil, errors.Wrapf(parseErr, "invalid %s in %s", key, path)
+		}
+		*target = n * multiplier
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+	return info, nil
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package idle provides a detector of the client connections with no traffic through their VPP interfaces
+package idle
+
+import (
+	"context"
+	"time"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/ifstats"
+)
+
+type entry struct {
+	packets uint64
+	since   time.Time
+	// reported - the connection is reported once until its traffic resumes
+	reported bool
+}
+
+// Detector - reports the ID of each client connection which counters of the received and the sent packets have not
+// advanced for longer than the timeout
+type Detector struct {
+	collector *ifstats.Collector
+	timeout   time.Duration
+	interval  time.Duration
+	idle      chan string
+
+	entries map[string]*entry
+}
+
+// NewDetector - creates a Detector checking the stats of collector each interval for the connections idle for longer
+// than timeout
+func NewDetector(collector *ifstats.Collector, timeout, interval time.Duration) *Detector {
+	return &Detector{
+		collector: collector,
+		timeout:   timeout,
+		interval:  interval,
+		idle:      make(chan string),
+		entries:   make(map[string]*entry),
+	}
+}
+
+// Idle - returns the channel the IDs of the idle connections are sent to
+func (d *Detector) Idle() <-chan string {
+	return d.idle
+}
+
+// Watch - checks the stats of the connections until ctx is done
+func (d *Detector) Watch(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for _, id := range d.check(time.Now()) {
+			select {
+			case <-ctx.Done():
+				return
+			case d.idle <- id:
+			}
+		}
+	}
+}
+
+// check - updates the entries with the stats polled by the collector, returns the IDs of the connections which have
+// become idle
+func (d *Detector) check(now time.Time) []string {
+	stats := d.collector.List()
+	for id := range d.entries {
+		if _, ok := stats[id]; !ok {
+			delete(d.entries, id)
+		}
+	}
+	var idle []string
+	for id, s := range stats {
+		packets := s.RxPackets + s.TxPackets
+		e, ok := d.entries[id]
+		if !ok || e.packets != packets {
+			d.entries[id] = &entry{packets: packets, since: now}
+			continue
+		}
+		if !e.reported && now.Sub(e.since) >= d.timeout {
+			e.reported = true
+			idle = append(idle, id)
+		}
+	}
+	return idle
+}
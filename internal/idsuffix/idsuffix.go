@@ -0,0 +1,49 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package idsuffix provides a unique suffix for connection IDs which is stable across restarts
+package idsuffix
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Load - returns the suffix stored in the file at path. If the file doesn't exist, a new suffix is generated and
+// stored to the file, so the following restarts get the same suffix.
+func Load(path string) (string, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err == nil {
+		if suffix := strings.TrimSpace(string(data)); suffix != "" {
+			return suffix, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return "", errors.Wrapf(err, "failed to read connection ID suffix from %s", path)
+	}
+
+	suffix := uuid.New().String()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", errors.Wrapf(err, "failed to create directory for %s", path)
+	}
+	if err := os.WriteFile(path, []byte(suffix), 0o600); err != nil {
+		return "", errors.Wrapf(err, "failed to store connection ID suffix to %s", path)
+	}
+	return suffix, nil
+}
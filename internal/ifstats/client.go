@@ -0,0 +1,60 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ifstats
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+type ifStatsClient struct {
+	collector *Collector
+}
+
+// NewClient - returns a client chain element registering the VPP interfaces of the connections in the collector.
+// Should be placed before the mechanism chain elements, so the interface is already created when Request returns.
+func NewClient(collector *Collector) networkservice.NetworkServiceClient {
+	return &ifStatsClient{
+		collector: collector,
+	}
+}
+
+func (c *ifStatsClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if swIfIndex, ok := ifindex.Load(ctx, true); ok {
+		c.collector.register(conn.GetId(), uint32(swIfIndex))
+	}
+	return conn, nil
+}
+
+func (c *ifStatsClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.collector.unregister(conn.GetId())
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
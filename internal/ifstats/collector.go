@@ -0,0 +1,174 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ifstats provides periodic polling of VPP statistics for the interfaces of the client connections
+package ifstats
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/adapter/statsclient"
+	"go.fd.io/govpp/api"
+	"go.fd.io/govpp/core"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Stats - counters of a VPP interface
+type Stats struct {
+	InterfaceName string
+	RxPackets     uint64
+	RxBytes       uint64
+	TxPackets     uint64
+	TxBytes       uint64
+	Drops         uint64
+}
+
+type entry struct {
+	swIfIndex uint32
+	stats     Stats
+	polled    bool
+}
+
+// Collector - polls stats of the interfaces registered by the client chain element
+type Collector struct {
+	statsSocket string
+	interval    time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+// NewCollector - creates a Collector polling the VPP stats socket every interval until ctx is done
+func NewCollector(ctx context.Context, statsSocket string, interval time.Duration) *Collector {
+	c := &Collector{
+		statsSocket: statsSocket,
+		interval:    interval,
+		entries:     make(map[string]*entry),
+	}
+	go c.run(ctx)
+	return c
+}
+
+// Load - returns the last polled stats for the connection
+func (c *Collector) Load(connID string) (Stats, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[connID]
+	if !ok || !e.polled {
+		return Stats{}, false
+	}
+	return e.stats, true
+}
+
+// List - returns the last polled stats of the registered connections by the connection ID
+func (c *Collector) List() map[string]Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := make(map[string]Stats, len(c.entries))
+	for connID, e := range c.entries {
+		if e.polled {
+			stats[connID] = e.stats
+		}
+	}
+	return stats
+}
+
+func (c *Collector) register(connID string, swIfIndex uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[connID]; ok && e.swIfIndex == swIfIndex {
+		return
+	}
+	c.entries[connID] = &entry{swIfIndex: swIfIndex}
+}
+
+func (c *Collector) unregister(connID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, connID)
+}
+
+func (c *Collector) run(ctx context.Context) {
+	logger := log.FromContext(ctx).WithField("ifstats", "Collector")
+
+	var statsConn *core.StatsConnection
+	defer func() {
+		if statsConn != nil {
+			statsConn.Disconnect()
+		}
+	}()
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if statsConn == nil {
+			var err error
+			if statsConn, err = core.ConnectStats(statsclient.NewStatsClient(c.statsSocket)); err != nil {
+				logger.Errorf("%+v", errors.Wrap(err, "failed to connect to Stats API"))
+				statsConn = nil
+				continue
+			}
+		}
+
+		stats := new(api.InterfaceStats)
+		if err := statsConn.GetInterfaceStats(stats); err != nil {
+			logger.Errorf("getting interface stats failed: %s", err.Error())
+			continue
+		}
+		c.update(ctx, stats)
+	}
+}
+
+func (c *Collector) update(ctx context.Context, stats *api.InterfaceStats) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for connID, e := range c.entries {
+		if int(e.swIfIndex) >= len(stats.Interfaces) {
+			continue
+		}
+		iface := &stats.Interfaces[e.swIfIndex]
+		e.stats = Stats{
+			InterfaceName: iface.InterfaceName,
+			RxPackets:     iface.Rx.Packets,
+			RxBytes:       iface.Rx.Bytes,
+			TxPackets:     iface.Tx.Packets,
+			TxBytes:       iface.Tx.Bytes,
+			Drops:         iface.Drops,
+		}
+		e.polled = true
+
+		log.FromContext(ctx).
+			WithField("connID", connID).
+			WithField("interface", e.stats.InterfaceName).
+			Debugf("rx_packets: %d, rx_bytes: %d, tx_packets: %d, tx_bytes: %d, drops: %d",
+				e.stats.RxPackets, e.stats.RxBytes, e.stats.TxPackets, e.stats.TxBytes, e.stats.Drops)
+	}
+}
@@ -2,44 +2,124 @@
 package imports
 
 import (
+	_ "bufio"
+	_ "bytes"
 	_ "context"
+	_ "crypto/subtle"
 	_ "crypto/tls"
+	_ "crypto/x509"
+	_ "encoding/binary"
+	_ "encoding/json"
 	_ "fmt"
 	_ "github.com/antonfisher/nested-logrus-formatter"
 	_ "github.com/edwarnicke/debug"
 	_ "github.com/edwarnicke/grpcfd"
+	_ "github.com/ghodss/yaml"
+	_ "github.com/golang-jwt/jwt/v4"
+	_ "github.com/golang/protobuf/ptypes/empty"
+	_ "github.com/google/uuid"
 	_ "github.com/kelseyhightower/envconfig"
+	_ "github.com/mdlayher/vsock"
 	_ "github.com/networkservicemesh/api/pkg/api/networkservice"
+	_ "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	_ "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	_ "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
+	_ "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vlan"
+	_ "github.com/networkservicemesh/api/pkg/api/networkservice/payload"
+	_ "github.com/networkservicemesh/govpp/binapi/ethernet_types"
+	_ "github.com/networkservicemesh/govpp/binapi/fib_types"
+	_ "github.com/networkservicemesh/govpp/binapi/interface"
+	_ "github.com/networkservicemesh/govpp/binapi/interface_types"
+	_ "github.com/networkservicemesh/govpp/binapi/ip"
+	_ "github.com/networkservicemesh/govpp/binapi/memif"
+	_ "github.com/networkservicemesh/govpp/binapi/ping"
+	_ "github.com/networkservicemesh/govpp/binapi/policer"
+	_ "github.com/networkservicemesh/govpp/binapi/policer_types"
 	_ "github.com/networkservicemesh/sdk-vpp/pkg/networkservice/connectioncontext"
 	_ "github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/memif"
+	_ "github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vlan"
 	_ "github.com/networkservicemesh/sdk-vpp/pkg/networkservice/up"
+	_ "github.com/networkservicemesh/sdk-vpp/pkg/networkservice/vrf"
 	_ "github.com/networkservicemesh/sdk-vpp/pkg/tools/heal"
+	_ "github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	_ "github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
 	_ "github.com/networkservicemesh/sdk/pkg/networkservice/chains/client"
 	_ "github.com/networkservicemesh/sdk/pkg/networkservice/common/clientinfo"
 	_ "github.com/networkservicemesh/sdk/pkg/networkservice/common/excludedprefixes"
 	_ "github.com/networkservicemesh/sdk/pkg/networkservice/common/heal"
 	_ "github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/sendfd"
+	_ "github.com/networkservicemesh/sdk/pkg/networkservice/common/monitor"
+	_ "github.com/networkservicemesh/sdk/pkg/networkservice/common/null"
 	_ "github.com/networkservicemesh/sdk/pkg/networkservice/common/retry"
 	_ "github.com/networkservicemesh/sdk/pkg/networkservice/common/upstreamrefresh"
+	_ "github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	_ "github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	_ "github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/awarenessgroups"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/clock"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/fs"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/ippool"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/log"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/log/logruslogger"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/nanoid"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/nsurl"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/postpone"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/pprofutils"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/token"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/tracing"
 	_ "github.com/networkservicemesh/vpphelper"
+	_ "github.com/pkg/errors"
+	_ "github.com/prometheus/client_golang/prometheus"
+	_ "github.com/prometheus/common/expfmt"
 	_ "github.com/sirupsen/logrus"
+	_ "github.com/spiffe/go-spiffe/v2/spiffeid"
 	_ "github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	_ "github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	_ "github.com/spiffe/go-spiffe/v2/workloadapi"
+	_ "go.fd.io/govpp/adapter/statsclient"
+	_ "go.fd.io/govpp/api"
+	_ "go.fd.io/govpp/binapi/vlib"
+	_ "go.fd.io/govpp/core"
+	_ "go.opentelemetry.io/otel"
+	_ "go.opentelemetry.io/otel/attribute"
+	_ "go.opentelemetry.io/otel/codes"
+	_ "go.opentelemetry.io/otel/exporters/prometheus"
+	_ "go.opentelemetry.io/otel/metric"
+	_ "go.opentelemetry.io/otel/metric/noop"
+	_ "go.opentelemetry.io/otel/sdk/metric"
+	_ "go.opentelemetry.io/otel/sdk/resource"
+	_ "go.opentelemetry.io/otel/semconv/v1.4.0"
+	_ "go.opentelemetry.io/otel/trace"
+	_ "golang.org/x/sys/unix"
 	_ "google.golang.org/grpc"
+	_ "google.golang.org/grpc/codes"
+	_ "google.golang.org/grpc/connectivity"
 	_ "google.golang.org/grpc/credentials"
+	_ "google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/keepalive"
+	_ "google.golang.org/grpc/status"
+	_ "google.golang.org/protobuf/encoding/protojson"
+	_ "io"
+	_ "math"
+	_ "math/rand"
+	_ "net"
+	_ "net/http"
 	_ "net/url"
 	_ "os"
 	_ "os/signal"
+	_ "path/filepath"
+	_ "reflect"
+	_ "regexp"
+	_ "runtime"
+	_ "runtime/debug"
+	_ "sort"
+	_ "strconv"
+	_ "strings"
+	_ "sync"
+	_ "sync/atomic"
 	_ "syscall"
 	_ "time"
 )
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package inflight provides a chain element limiting the number of the requests to NSMgr in flight at once
+package inflight
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Limiter - semaphore shared by the clients of all NSMgrs, so the limit applies to the whole process
+type Limiter struct {
+	slots chan struct{}
+}
+
+// NewLimiter - creates a Limiter allowing maxInFlight requests at once
+func NewLimiter(maxInFlight int) *Limiter {
+	return &Limiter{
+		slots: make(chan struct{}, maxInFlight),
+	}
+}
+
+// acquire - takes a slot, waits until one is free or ctx is done. The wait is logged, so the queued requests are seen.
+func (l *Limiter) acquire(ctx context.Context, id string) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	now := time.Now()
+	logger := log.FromContext(ctx).WithField("id", id)
+	logger.Infof("request is queued, %d requests are in flight", cap(l.slots))
+	select {
+	case l.slots <- struct{}{}:
+		logger.WithField("duration", time.Since(now)).Info("queued request is sent")
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "request is cancelled while queued")
+	}
+}
+
+func (l *Limiter) release() {
+	<-l.slots
+}
+
+type inFlightClient struct {
+	limiter *Limiter
+}
+
+// NewClient - returns a client chain element holding a slot of limiter while the request is sent to NSMgr, the
+// requests beyond the limit wait for a free slot. Covers all requests going through the chain: the ones of the
+// client, the refreshes and the heals. Should be placed last, so the VPP configuration is done outside the slot.
+func NewClient(limiter *Limiter) networkservice.NetworkServiceClient {
+	return &inFlightClient{
+		limiter: limiter,
+	}
+}
+
+func (c *inFlightClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	if err := c.limiter.acquire(ctx, request.GetConnection().GetId()); err != nil {
+		return nil, err
+	}
+	defer c.limiter.release()
+
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (c *inFlightClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package insecure provides gRPC credentials for running the client without SPIFFE, e.g. for local testing
+package insecure
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+)
+
+const signingKey = "insecure"
+
+type insecurePerRPCCredentials struct {
+	credentials.PerRPCCredentials
+}
+
+func (i *insecurePerRPCCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// DialOptions - returns dial options allowing per RPC credentials to be sent over insecure connections
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(ctx, method, req, reply, cc, withInsecureRPCCredentials(opts)...)
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return streamer(ctx, desc, cc, method, withInsecureRPCCredentials(opts)...)
+		}),
+	}
+}
+
+func withInsecureRPCCredentials(opts []grpc.CallOption) []grpc.CallOption {
+	for i := len(opts) - 1; i > -1; i-- {
+		if v, ok := opts[i].(grpc.PerRPCCredsCallOption); ok {
+			return append(opts, grpc.PerRPCCredentials(&insecurePerRPCCredentials{PerRPCCredentials: v.Creds}))
+		}
+	}
+	return opts
+}
+
+// TokenGeneratorFunc - returns a generator of static tokens for the given subject signed with a well known key
+func TokenGeneratorFunc(subject string, maxTokenLifetime time.Duration) token.GeneratorFunc {
+	return func(_ credentials.AuthInfo) (string, time.Time, error) {
+		expireTime := time.Now().Add(maxTokenLifetime)
+
+		claims := jwt.RegisteredClaims{
+			Subject:   subject,
+			ExpiresAt: jwt.NewNumericDate(expireTime),
+		}
+
+		tok, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(signingKey))
+		return tok, expireTime, errors.Wrapf(err, "failed to create a new Token, method %s, subject %s", jwt.SigningMethodHS256.Name, claims.Subject)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package interfacesfile
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.fd.io/govpp/api"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/statusfile"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/tenant"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+type interfacesFileClient struct {
+	vppConn api.Connection
+	writer  *Writer
+}
+
+// NewClient - returns a client chain element storing the interfaces of the established and healed connections in the
+// writer. Should be placed before the mechanism chain elements, so the interface is already created when Request
+// returns.
+func NewClient(vppConn api.Connection, writer *Writer) networkservice.NetworkServiceClient {
+	return &interfacesFileClient{
+		vppConn: vppConn,
+		writer:  writer,
+	}
+}
+
+func (c *interfacesFileClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	iface := &Interface{
+		NetworkService: conn.GetNetworkService(),
+		ConnectionID:   conn.GetId(),
+		Mechanism:      conn.GetMechanism().GetType(),
+		Tenant:         tenant.FromContext(ctx),
+	}
+	if mech := kernel.ToMechanism(conn.GetMechanism()); mech != nil {
+		iface.HostInterface = mech.GetInterfaceName()
+	}
+	if swIfIndex, ok := ifindex.Load(ctx, true); ok {
+		iface.SwIfIndex = uint32(swIfIndex)
+		if iface.VppInterface, err = statusfile.InterfaceName(ctx, c.vppConn, swIfIndex); err != nil {
+			log.FromContext(ctx).Warnf("failed to get interface name: %s", err.Error())
+		}
+	}
+	if err = c.writer.Store(iface); err != nil {
+		log.FromContext(ctx).Errorf("failed to update interfaces file: %s", err.Error())
+	}
+	return conn, nil
+}
+
+func (c *interfacesFileClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.writer.Delete(conn.GetId()); err != nil {
+		log.FromContext(ctx).Errorf("failed to update interfaces file: %s", err.Error())
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package interfacesfile provides a JSON file mapping the network services to the interfaces of their connections
+// for CNI chaining and observability tools
+package interfacesfile
+
+import (
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/statusfile"
+)
+
+// SchemaVersion - version of the file schema, changed only on incompatible changes
+const SchemaVersion = 1
+
+// Interface - interface of an established connection
+type Interface struct {
+	NetworkService string `json:"networkService"`
+	ConnectionID   string `json:"connectionId"`
+	Mechanism      string `json:"mechanism"`
+	VppInterface   string `json:"vppInterface,omitempty"`
+	SwIfIndex      uint32 `json:"swIfIndex,omitempty"`
+	HostInterface  string `json:"hostInterface,omitempty"`
+	Tenant         string `json:"tenant,omitempty"`
+}
+
+// File - content of the interfaces file
+type File struct {
+	Version    int          `json:"version"`
+	Interfaces []*Interface `json:"interfaces"`
+}
+
+// Writer - keeps the interfaces file up to date with the stored interfaces
+type Writer struct {
+	path string
+
+	mu         sync.Mutex
+	interfaces map[string]*Interface
+	removed    bool
+}
+
+// NewWriter - creates a Writer for the file at path
+func NewWriter(path string) *Writer {
+	return &Writer{
+		path:       path,
+		interfaces: make(map[string]*Interface),
+	}
+}
+
+// Store - adds or updates the interface of the connection and rewrites the file
+func (w *Writer) Store(iface *Interface) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.removed {
+		return nil
+	}
+	w.interfaces[iface.ConnectionID] = iface
+	return w.write()
+}
+
+// Delete - deletes the interface of the connection and rewrites the file
+func (w *Writer) Delete(connectionID string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.interfaces[connectionID]; !ok || w.removed {
+		return nil
+	}
+	delete(w.interfaces, connectionID)
+	return w.write()
+}
+
+// Remove - removes the file, should be called on shutdown. The file is not written anymore afterwards, so the
+// connections closed during the shutdown don't bring it back.
+func (w *Writer) Remove() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.interfaces = make(map[string]*Interface)
+	w.removed = true
+	if err := os.Remove(w.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove interfaces file %s", w.path)
+	}
+	return nil
+}
+
+func (w *Writer) write() error {
+	file := &File{
+		Version:    SchemaVersion,
+		Interfaces: make([]*Interface, 0, len(w.interfaces)),
+	}
+	for _, iface := range w.interfaces {
+		file.Interfaces = append(file.Interfaces, iface)
+	}
+	sort.Slice(file.Interfaces, func(i, j int) bool {
+		return file.Interfaces[i].ConnectionID < file.Interfaces[j].ConnectionID
+	})
+
+	return statusfile.WriteJSON(w.path, file)
+}
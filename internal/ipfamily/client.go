@@ -0,0 +1,105 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ipfamily provides a chain element checking that the connections get the addresses of the required IP family
+package ipfamily
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+const (
+	// IPv4 - connections must get an IPv4 source address
+	IPv4 = "ipv4"
+	// IPv6 - connections must get an IPv6 source address
+	IPv6 = "ipv6"
+	// DualStack - connections must get both IPv4 and IPv6 source addresses
+	DualStack = "dualstack"
+)
+
+// Validate - returns an error if family is not one of IPv4, IPv6, DualStack or empty
+func Validate(family string) error {
+	switch strings.ToLower(family) {
+	case "", IPv4, IPv6, DualStack:
+		return nil
+	default:
+		return errors.Errorf("unsupported IP family %q, expected one of: %s, %s, %s", family, IPv4, IPv6, DualStack)
+	}
+}
+
+type ipFamilyClient struct {
+	v4, v6 bool
+}
+
+// NewClient - returns a client chain element requiring the source addresses of the family for each connection.
+// If the NSE doesn't provide them, the connection is closed and Request returns an error.
+func NewClient(family string) networkservice.NetworkServiceClient {
+	family = strings.ToLower(family)
+	return &ipFamilyClient{
+		v4: family == IPv4 || family == DualStack,
+		v6: family == IPv6 || family == DualStack,
+	}
+}
+
+func (c *ipFamilyClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	if request.GetConnection().GetContext() == nil {
+		request.GetConnection().Context = &networkservice.ConnectionContext{}
+	}
+	if request.GetConnection().GetContext().GetIpContext() == nil {
+		request.GetConnection().GetContext().IpContext = &networkservice.IPContext{}
+	}
+	request.GetConnection().GetContext().GetIpContext().SrcIpRequired = true
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var v4, v6 bool
+	for _, ipNet := range conn.GetContext().GetIpContext().GetSrcIPNets() {
+		if ipNet.IP.To4() != nil {
+			v4 = true
+		} else {
+			v6 = true
+		}
+	}
+	if (c.v4 && !v4) || (c.v6 && !v6) {
+		err = errors.Errorf("connection %s has source addresses %v, missing addresses of the required IP family",
+			conn.GetId(), conn.GetContext().GetIpContext().GetSrcIpAddrs())
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+		if _, closeErr := next.Client(ctx).Close(closeCtx, conn, opts...); closeErr != nil {
+			log.FromContext(ctx).Errorf("failed to close connection %s: %s", conn.GetId(), closeErr.Error())
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (c *ipFamilyClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
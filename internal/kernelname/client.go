@@ -0,0 +1,86 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package kernelname provides a chain element preparing the kernel mechanism preferences of the requests, so the
+// kernel interface is created in the client network namespace with a predictable name
+package kernelname
+
+import (
+	"context"
+	"net"
+	"net/url"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/nanoid"
+)
+
+var netNSURL = (&url.URL{Scheme: "file", Path: "/proc/thread-self/ns/net"}).String()
+
+type key struct{}
+
+type kernelNameClient struct{}
+
+// NewClient - returns a client chain element setting the network namespace of the kernel mechanism preferences and
+// generating the interface name if it is not set. The first request of the connection fails if an interface with the
+// name already exists.
+func NewClient() networkservice.NetworkServiceClient {
+	return &kernelNameClient{}
+}
+
+func (c *kernelNameClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	_, established := metadata.Map(ctx, true).Load(key{})
+	for _, m := range request.GetMechanismPreferences() {
+		mech := kernel.ToMechanism(m)
+		if mech == nil {
+			continue
+		}
+		mech.SetNetNSURL(netNSURL)
+		if mech.GetInterfaceName() == "" {
+			name, err := nanoid.GenerateLinuxInterfaceName(request.GetConnection().GetNetworkService())
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to generate kernel interface name")
+			}
+			mech.SetInterfaceName(name)
+		}
+		if established {
+			continue
+		}
+		if _, err := net.InterfaceByName(mech.GetInterfaceName()); err == nil {
+			return nil, errors.Errorf("kernel interface %s already exists in the client network namespace", mech.GetInterfaceName())
+		}
+	}
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+	metadata.Map(ctx, true).Store(key{}, struct{}{})
+	return conn, nil
+}
+
+func (c *kernelNameClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
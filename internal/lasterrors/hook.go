@@ -0,0 +1,76 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lasterrors provides a logrus hook keeping the last warnings and errors logged
+package lasterrors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Entry - a logged warning or error
+type Entry struct {
+	Time    time.Time
+	Level   logrus.Level
+	Message string
+}
+
+// Hook - logrus hook keeping the last warnings and errors
+type Hook struct {
+	size int
+
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// NewHook - creates a Hook keeping the last size warnings and errors
+func NewHook(size int) *Hook {
+	return &Hook{
+		size: size,
+	}
+}
+
+// Levels - returns the levels of the warnings and the errors
+func (h *Hook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel, logrus.WarnLevel}
+}
+
+// Fire - keeps the entry, the oldest one is dropped if there are size of them already
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.entries) == h.size {
+		h.entries = h.entries[1:]
+	}
+	h.entries = append(h.entries, Entry{
+		Time:    entry.Time,
+		Level:   entry.Level,
+		Message: entry.Message,
+	})
+	return nil
+}
+
+// Entries - returns the kept warnings and errors from the oldest to the latest
+func (h *Hook) Entries() []Entry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]Entry(nil), h.entries...)
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package linkup provides a chain element failing the connections whose VPP interface link doesn't come up in time
+package linkup
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	interfaces "github.com/networkservicemesh/govpp/binapi/interface"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+type linkUpClient struct {
+	vppConn api.Connection
+	timeout time.Duration
+}
+
+// NewClient - returns a client chain element waiting for the link of the client interface to come up for timeout
+// after the Request. If it doesn't, the connection is closed and Request returns an error, so it is retried.
+// Should be placed before up chain element, so the interface is already admin up when Request returns.
+func NewClient(vppConn api.Connection, timeout time.Duration) networkservice.NetworkServiceClient {
+	return &linkUpClient{
+		vppConn: vppConn,
+		timeout: timeout,
+	}
+}
+
+func (c *linkUpClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	swIfIndex, ok := ifindex.Load(ctx, true)
+	if !ok {
+		return conn, nil
+	}
+	if err = waitForLinkUp(ctx, c.vppConn, swIfIndex, c.timeout); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+		if _, closeErr := next.Client(ctx).Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (c *linkUpClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func waitForLinkUp(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, timeout time.Duration) error {
+	logger := log.FromContext(ctx).WithField("swIfIndex", swIfIndex)
+
+	watcher, err := vppConn.WatchEvent(ctx, &interfaces.SwInterfaceEvent{})
+	if err != nil {
+		return errors.Wrap(err, "failed to watch interfaces.SwInterfaceEvent")
+	}
+	defer watcher.Close()
+
+	flags, err := interfaceFlags(ctx, vppConn, swIfIndex)
+	if err != nil {
+		return err
+	}
+	if flags&interface_types.IF_STATUS_API_FLAG_LINK_UP != 0 {
+		logger.Debugf("link is up, admin up: %t", flags&interface_types.IF_STATUS_API_FLAG_ADMIN_UP != 0)
+		return nil
+	}
+
+	now := time.Now()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "provided context is done")
+		case <-timer.C:
+			if flags, err = interfaceFlags(ctx, vppConn, swIfIndex); err != nil {
+				return err
+			}
+			logger.Warnf("link is not up in %s, admin up: %t, link up: %t", timeout,
+				flags&interface_types.IF_STATUS_API_FLAG_ADMIN_UP != 0, flags&interface_types.IF_STATUS_API_FLAG_LINK_UP != 0)
+			return errors.Errorf("link of interface %d is not up in %s", swIfIndex, timeout)
+		case rawMsg := <-watcher.Events():
+			if msg, ok := rawMsg.(*interfaces.SwInterfaceEvent); ok &&
+				msg.SwIfIndex == swIfIndex &&
+				msg.Flags&interface_types.IF_STATUS_API_FLAG_LINK_UP != 0 {
+				logger.WithField("duration", time.Since(now)).Debugf("link is up, admin up: %t", msg.Flags&interface_types.IF_STATUS_API_FLAG_ADMIN_UP != 0)
+				return nil
+			}
+		}
+	}
+}
+
+func interfaceFlags(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) (interface_types.IfStatusFlags, error) {
+	dc, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{
+		SwIfIndex: swIfIndex,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "vppapi SwInterfaceDump returned error")
+	}
+	defer func() { _ = dc.Close() }()
+
+	var flags interface_types.IfStatusFlags
+	for {
+		details, recvErr := dc.Recv()
+		if recvErr == io.EOF {
+			return flags, nil
+		}
+		if recvErr != nil {
+			return 0, errors.Wrapf(recvErr, "error retrieving SwInterfaceDetails for swIfIndex %d", swIfIndex)
+		}
+		flags = details.Flags
+	}
+}
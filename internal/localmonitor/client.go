@@ -0,0 +1,56 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package localmonitor
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type localMonitorClient struct {
+	server *Server
+}
+
+// NewClient - returns a client chain element sending the established connections to server as UPDATE events and the
+// closed ones as DELETE events. Heal and refresh requests go through the chain too, so they are sent as well.
+func NewClient(server *Server) networkservice.NetworkServiceClient {
+	return &localMonitorClient{
+		server: server,
+	}
+}
+
+func (m *localMonitorClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+	m.server.send(networkservice.ConnectionEventType_UPDATE, conn)
+	return conn, nil
+}
+
+func (m *localMonitorClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	m.server.send(networkservice.ConnectionEventType_DELETE, conn)
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package localmonitor provides a MonitorConnection server re-broadcasting the state of the client connections to
+// the other containers of the pod, so they don't need to talk to NSMgr
+package localmonitor
+
+import (
+	"context"
+	"net/url"
+	"os"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/monitor"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// socketMode - only the user and the group of the client can connect to the socket
+const socketMode os.FileMode = 0o660
+
+// Server - MonitorConnection server with the connections of the client, sends INITIAL_STATE_TRANSFER to the new
+// subscribers and UPDATE/DELETE events on request, heal and close
+type Server struct {
+	networkservice.MonitorConnectionServer
+	events monitor.EventConsumer
+}
+
+// NewServer - creates a Server living until ctx is done
+func NewServer(ctx context.Context) *Server {
+	s := new(Server)
+	// Only the MonitorConnectionServer of the monitor server chain element is used, it keeps the connections and
+	// broadcasts the events to the subscribers
+	_ = monitor.NewServer(ctx, &s.MonitorConnectionServer)
+	s.events = s.MonitorConnectionServer.(monitor.EventConsumer)
+	return s
+}
+
+// ListenAndServe - serves the MonitorConnection API on the unix socket at path until ctx is done
+func (s *Server) ListenAndServe(ctx context.Context, path string) error {
+	server := grpc.NewServer()
+	networkservice.RegisterMonitorConnectionServer(server, s)
+
+	errCh := grpcutils.ListenAndServe(ctx, &url.URL{Scheme: "unix", Path: path}, server)
+	select {
+	case err := <-errCh:
+		return errors.Wrapf(err, "failed to serve the monitor API on %s", path)
+	default:
+	}
+	if err := os.Chmod(path, socketMode); err != nil {
+		return errors.Wrapf(err, "failed to change the mode of %s", path)
+	}
+	log.FromContext(ctx).Infof("Monitor API is served on %s", path)
+
+	go func() {
+		if err, ok := <-errCh; ok {
+			log.FromContext(ctx).Errorf("monitor API server has failed: %s", err.Error())
+		}
+	}()
+	return nil
+}
+
+func (s *Server) send(eventType networkservice.ConnectionEventType, conn *networkservice.Connection) {
+	_ = s.events.Send(&networkservice.ConnectionEvent{
+		Type: eventType,
+		Connections: map[string]*networkservice.Connection{
+			conn.GetId(): conn.Clone(),
+		},
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package macaddr provides a chain element setting the MAC address requested for the client interface
+package macaddr
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/govpp/binapi/ethernet_types"
+	interfaces "github.com/networkservicemesh/govpp/binapi/interface"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+type macAddrClient struct {
+	vppConn api.Connection
+}
+
+// NewClient - returns a client chain element setting the MAC address of the VPP interface of the connection to the
+// source MAC of the ethernet context of the request, the interface keeps the generated one if it is not set. If the
+// MAC can't be set, the connection is closed and Request returns an error. Should be placed after up chain element, so
+// the MAC is set before the interface is admin up.
+func NewClient(vppConn api.Connection) networkservice.NetworkServiceClient {
+	return &macAddrClient{
+		vppConn: vppConn,
+	}
+}
+
+func (c *macAddrClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	mac := request.GetConnection().GetContext().GetEthernetContext().GetSrcMac()
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil || mac == "" {
+		return conn, err
+	}
+
+	swIfIndex, ok := ifindex.Load(ctx, true)
+	if !ok {
+		return conn, nil
+	}
+	if err = setMacAddress(ctx, c.vppConn, swIfIndex, mac); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+		if _, closeErr := next.Client(ctx).Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (c *macAddrClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func setMacAddress(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, mac string) error {
+	macAddress, err := ethernet_types.ParseMacAddress(mac)
+	if err != nil {
+		return errors.Wrapf(err, "invalid MAC address %q", mac)
+	}
+	now := time.Now()
+	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetMacAddress(ctx, &interfaces.SwInterfaceSetMacAddress{
+		SwIfIndex:  swIfIndex,
+		MacAddress: macAddress,
+	}); err != nil {
+		return errors.Wrapf(err, "vppapi SwInterfaceSetMacAddress returned error for MAC address %s", mac)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("mac", mac).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetMacAddress").Debug("completed")
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package memifrole
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	memifmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type memifRoleClient struct {
+	role string
+}
+
+// NewClient - returns a client chain element selecting the role of the client memif interface of the connections.
+// With Auto the role returned in the memif mechanism is taken, otherwise the returned role should match role or the
+// connection is closed and Request returns an error. A peer returning no role, e.g. the forwarder, is the master, so
+// Master is rejected then. Should be placed right after the memif chain element, so the
+// role is selected before the interface is created, and the memif chain element should use the VPP connection
+// returned by NewConnection.
+func NewClient(role string) networkservice.NetworkServiceClient {
+	return &memifRoleClient{
+		role: role,
+	}
+}
+
+func (c *memifRoleClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return conn, err
+	}
+	mechanism := memifmech.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return conn, nil
+	}
+
+	role := c.role
+	returned := conn.GetMechanism().GetParameters()[Key]
+	switch {
+	case returned != "" && returned != Master && returned != Slave:
+		err = errors.Errorf("invalid memif role %q returned, should be %s or %s", returned, Master, Slave)
+	case role == Auto && returned != "":
+		role = returned
+	case role == Auto:
+		role = Slave
+	case returned != "" && returned != role:
+		err = errors.Errorf("memif role %s is required, the memif mechanism allows %s only", role, returned)
+	case returned == "" && role == Master:
+		err = errors.Errorf("memif role %s is required, no role is returned in the memif mechanism, so the peer is the master", role)
+	}
+	if err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+		if _, closeErr := next.Client(ctx).Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+		return nil, err
+	}
+
+	if previous, ok := Load(ctx); !ok || previous != role {
+		log.FromContext(ctx).WithField("memifrole", "Request").Infof("memif role of connection %s is %s", conn.GetId(), role)
+	}
+	store(ctx, role)
+	return conn, nil
+}
+
+func (c *memifRoleClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package memifrole_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	memifmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/memifrole"
+)
+
+// returnedRoleClient - establishes the connections with the memif mechanism returning role, none if empty
+type returnedRoleClient struct {
+	role string
+}
+
+func (c *returnedRoleClient) Request(_ context.Context, request *networkservice.NetworkServiceRequest, _ ...grpc.CallOption) (*networkservice.Connection, error) {
+	conn := request.GetConnection().Clone()
+	conn.Mechanism = &networkservice.Mechanism{Type: memifmech.MECHANISM, Parameters: map[string]string{}}
+	if c.role != "" {
+		conn.GetMechanism().GetParameters()[memifrole.Key] = c.role
+	}
+	return conn, nil
+}
+
+func (c *returnedRoleClient) Close(context.Context, *networkservice.Connection, ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func TestMemifRoleClient(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		role     string
+		returned string
+		wantErr  bool
+	}{
+		{name: "auto with no role returned", role: memifrole.Auto},
+		{name: "auto with master returned", role: memifrole.Auto, returned: memifrole.Master},
+		{name: "slave with no role returned", role: memifrole.Slave},
+		{name: "master with no role returned", role: memifrole.Master, wantErr: true},
+		{name: "master with master returned", role: memifrole.Master, returned: memifrole.Master},
+		{name: "master with slave returned", role: memifrole.Master, returned: memifrole.Slave, wantErr: true},
+		{name: "invalid role returned", role: memifrole.Auto, returned: "peer", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := chain.NewNetworkServiceClient(
+				metadata.NewClient(),
+				memifrole.NewClient(tc.role),
+				&returnedRoleClient{role: tc.returned},
+			)
+			_, err := client.Request(context.Background(), &networkservice.NetworkServiceRequest{
+				Connection: &networkservice.Connection{Id: "nsc-0"},
+			})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
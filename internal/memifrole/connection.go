@@ -0,0 +1,83 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package memifrole provides a chain element selecting the role of the client memif interfaces and a VPP connection
+// creating them with it
+package memifrole
+
+import (
+	"context"
+
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/govpp/binapi/memif"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+const (
+	// Key - memif mechanism parameter with the role of the client memif interface
+	Key = "role"
+
+	// Auto - the client takes the role returned by the NSE or the forwarder, slave if none is returned
+	Auto = "auto"
+	// Master - the client memif interface is the master
+	Master = "master"
+	// Slave - the client memif interface is the slave
+	Slave = "slave"
+)
+
+type roleKey struct{}
+
+func store(ctx context.Context, role string) {
+	metadata.Map(ctx, true).Store(roleKey{}, role)
+}
+
+// Load - returns the role of the client memif interface of the connection stored by the chain element
+func Load(ctx context.Context) (string, bool) {
+	rawValue, ok := metadata.Map(ctx, true).Load(roleKey{})
+	if !ok {
+		return "", false
+	}
+	value, ok := rawValue.(string)
+	return value, ok
+}
+
+type memifRoleConnection struct {
+	api.Connection
+}
+
+// NewConnection - returns vppConn creating the memif interfaces with the role stored by the chain element for the
+// connection, the role set by the caller is kept if none is stored
+func NewConnection(vppConn api.Connection) api.Connection {
+	return &memifRoleConnection{
+		Connection: vppConn,
+	}
+}
+
+func (c *memifRoleConnection) Invoke(ctx context.Context, req, reply api.Message) error {
+	if memifCreate, ok := req.(*memif.MemifCreate); ok {
+		switch role, _ := Load(ctx); role {
+		case Master:
+			memifCreate.Role = memif.MEMIF_ROLE_API_MASTER
+		case Slave:
+			memifCreate.Role = memif.MEMIF_ROLE_API_SLAVE
+		}
+	}
+	return c.Connection.Invoke(ctx, req, reply)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package memifsize provides a VPP connection setting the ring and buffer sizes of the memif interfaces created
+// through it
+package memifsize
+
+import (
+	"context"
+
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/govpp/binapi/memif"
+)
+
+const (
+	// RingSizeKey - memif mechanism parameter with the number of entries of the RX/TX rings
+	RingSizeKey = "ring_size"
+	// BufferSizeKey - memif mechanism parameter with the size of the buffer allocated for each ring entry
+	BufferSizeKey = "buffer_size"
+)
+
+type memifSizeConnection struct {
+	api.Connection
+	ringSize   uint32
+	bufferSize uint16
+}
+
+// NewConnection - returns vppConn setting ringSize and bufferSize on the memif interfaces it creates, the VPP
+// default is kept for a zero size
+func NewConnection(vppConn api.Connection, ringSize uint32, bufferSize uint16) api.Connection {
+	if ringSize == 0 && bufferSize == 0 {
+		return vppConn
+	}
+	return &memifSizeConnection{
+		Connection: vppConn,
+		ringSize:   ringSize,
+		bufferSize: bufferSize,
+	}
+}
+
+func (c *memifSizeConnection) Invoke(ctx context.Context, req, reply api.Message) error {
+	if memifCreate, ok := req.(*memif.MemifCreate); ok {
+		if memifCreate.RingSize == 0 {
+			memifCreate.RingSize = c.ringSize
+		}
+		if memifCreate.BufferSize == 0 {
+			memifCreate.BufferSize = c.bufferSize
+		}
+	}
+	return c.Connection.Invoke(ctx, req, reply)
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package memifsocket provides a chain element placing the sockets of the client memif masters in a directory
+package memifsocket
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	memifmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/memifrole"
+)
+
+type memifSocketClient struct {
+	dir string
+}
+
+// NewClient - returns a client chain element setting the socket of the memif connections the client is the master of
+// to a file named after the connection ID in dir, so VPP creates it there and the peer finds it in the shared
+// directory. A file left by a previous run is removed. Does nothing if dir is empty.
+// Should be placed between the memif and the memifrole chain elements, so the role is known and the memif interface
+// is not created yet when Request returns.
+func NewClient(dir string) networkservice.NetworkServiceClient {
+	return &memifSocketClient{
+		dir: dir,
+	}
+}
+
+func (c *memifSocketClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil || c.dir == "" {
+		return conn, err
+	}
+	mechanism := memifmech.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return conn, nil
+	}
+	if role, _ := memifrole.Load(ctx); role != memifrole.Master {
+		return conn, nil
+	}
+
+	socketFile := filepath.Join(c.dir, conn.GetId()+".sock")
+	if mechanism.GetSocketFilename() != socketFile {
+		if err := os.Remove(socketFile); err != nil && !os.IsNotExist(err) {
+			log.FromContext(ctx).Warnf("failed to remove memif socket %s: %s", socketFile, err.Error())
+		}
+		mechanism.SetSocketFilename(socketFile)
+	}
+	return conn, nil
+}
+
+func (c *memifSocketClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
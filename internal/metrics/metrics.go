@@ -0,0 +1,107 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides OpenTelemetry metrics of the application level events
+package metrics
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
+)
+
+const meterName = "github.com/networkservicemesh/cmd-nsc-vpp"
+
+// Attribute keys of the metrics
+const (
+	NetworkServiceKey = attribute.Key("nsm.network_service")
+	ConnectionIDKey   = attribute.Key("nsm.connection_id")
+	TenantKey         = attribute.Key("nsm.tenant")
+)
+
+// initialized - a meter provider is set by Init
+var initialized atomic.Bool
+
+// Init - sets the global meter provider exporting the metrics to readers, so that the metrics are collected even if
+// OpenTelemetry is disabled, e.g. only for the metrics file. The provider should be shut down on exit.
+func Init(ctx context.Context, service string, readers ...sdkmetric.Reader) *sdkmetric.MeterProvider {
+	var options []sdkmetric.Option
+	if res, err := resource.New(ctx, resource.WithAttributes(semconv.ServiceNameKey.String(service))); err == nil {
+		options = append(options, sdkmetric.WithResource(res))
+	} else {
+		log.FromContext(ctx).Errorf("failed to create metrics resource: %s", err.Error())
+	}
+	for _, reader := range readers {
+		options = append(options, sdkmetric.WithReader(reader))
+	}
+	meterProvider := sdkmetric.NewMeterProvider(options...)
+	otel.SetMeterProvider(meterProvider)
+	initialized.Store(true)
+	return meterProvider
+}
+
+// Enabled - returns true if the metrics are collected: OpenTelemetry is enabled or a meter provider is set by Init
+func Enabled() bool {
+	return opentelemetry.IsEnabled() || initialized.Load()
+}
+
+// Meter - returns the meter of the client if the metrics are enabled, otherwise a no-op meter. Should be called after
+// OpenTelemetry or the meter provider is initialized.
+func Meter() metric.Meter {
+	if !Enabled() {
+		return noop.NewMeterProvider().Meter(meterName)
+	}
+	return otel.Meter(meterName)
+}
+
+// Int64Counter - returns the counter with the name from Meter, a no-op counter if it fails to be created
+func Int64Counter(name, description string) metric.Int64Counter {
+	counter, err := Meter().Int64Counter(name, metric.WithDescription(description))
+	if err != nil {
+		log.L().Errorf("failed to create %s counter: %s", name, err.Error())
+		counter, _ = noop.NewMeterProvider().Meter(meterName).Int64Counter(name)
+	}
+	return counter
+}
+
+// Float64ObservableGauge - registers the gauge with the name on Meter, callback is called on each collection. Nothing
+// is registered if the gauge fails to be created.
+func Float64ObservableGauge(name, description string, callback metric.Float64Callback) {
+	if _, err := Meter().Float64ObservableGauge(name, metric.WithDescription(description), metric.WithFloat64Callback(callback)); err != nil {
+		log.L().Errorf("failed to create %s gauge: %s", name, err.Error())
+	}
+}
+
+// Float64Histogram - returns the histogram with the name and the unit from Meter, a no-op histogram if it fails to be
+// created
+func Float64Histogram(name, description, unit string) metric.Float64Histogram {
+	histogram, err := Meter().Float64Histogram(name, metric.WithDescription(description), metric.WithUnit(unit))
+	if err != nil {
+		log.L().Errorf("failed to create %s histogram: %s", name, err.Error())
+		histogram, _ = noop.NewMeterProvider().Meter(meterName).Float64Histogram(name)
+	}
+	return histogram
+}
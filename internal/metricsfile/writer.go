@@ -0,0 +1,123 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metricsfile provides a file with the metrics of the client in the OpenMetrics text format for file-based
+// collection
+package metricsfile
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/statusfile"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Writer - writes the metrics collected by its reader to the file
+type Writer struct {
+	path     string
+	registry *prometheus.Registry
+	reader   sdkmetric.Reader
+
+	mu        sync.Mutex
+	truncated bool
+}
+
+// NewWriter - creates a Writer for the file at path, its Reader should be registered on the meter provider
+func NewWriter(path string) (*Writer, error) {
+	registry := prometheus.NewRegistry()
+	exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry), otelprometheus.WithoutScopeInfo())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Prometheus exporter for metrics file")
+	}
+	return &Writer{
+		path:     path,
+		registry: registry,
+		reader:   exporter,
+	}, nil
+}
+
+// Reader - returns the reader collecting the metrics written to the file
+func (w *Writer) Reader() sdkmetric.Reader {
+	return w.reader
+}
+
+// Run - writes the file each interval until ctx is done
+func (w *Writer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := w.Write(); err != nil {
+			log.FromContext(ctx).Error(err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Write - atomically replaces the file with the current metrics
+func (w *Writer) Write() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.truncated {
+		return nil
+	}
+	families, err := w.registry.Gather()
+	if err != nil {
+		return errors.Wrap(err, "failed to gather metrics")
+	}
+	var buf bytes.Buffer
+	encoder := expfmt.NewEncoder(&buf, expfmt.FmtOpenMetrics_1_0_0)
+	for _, family := range families {
+		if err = encoder.Encode(family); err != nil {
+			return errors.Wrapf(err, "failed to encode metric %s", family.GetName())
+		}
+	}
+	// OpenMetrics needs the final # EOF line
+	if closer, ok := encoder.(expfmt.Closer); ok {
+		if err = closer.Close(); err != nil {
+			return errors.Wrap(err, "failed to encode metrics")
+		}
+	}
+	return statusfile.WriteFile(w.path, buf.Bytes())
+}
+
+// Truncate - truncates the file, should be called on shutdown. The file is not written anymore afterwards, so the
+// collectors don't see the metrics of a stopped client.
+func (w *Writer) Truncate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.truncated = true
+	if err := os.Truncate(w.path, 0); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to truncate metrics file %s", w.path)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package none provides the NONE mechanism for the network services with no dataplane interface on the client, used
+// for pure signaling and IPAM and for testing the NSM control plane
+package none
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+// MECHANISM - type of the mechanism with no interface, requested with none://service NSURL
+const MECHANISM = "NONE"
+
+type noneClient struct {
+	dataplane networkservice.NetworkServiceClient
+}
+
+// NewClient - returns a client chain element passing the connections with the NONE mechanism straight to the next
+// element, and the other connections through the dataplane chain elements creating and configuring their
+// interfaces in VPP
+func NewClient(dataplane ...networkservice.NetworkServiceClient) networkservice.NetworkServiceClient {
+	return &noneClient{
+		dataplane: next.NewNetworkServiceClient(dataplane...),
+	}
+}
+
+func (n *noneClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	mech := request.GetConnection().GetMechanism()
+	if mech == nil && len(request.GetMechanismPreferences()) > 0 {
+		mech = request.GetMechanismPreferences()[0]
+	}
+	if mech.GetType() == MECHANISM {
+		return next.Client(ctx).Request(ctx, request, opts...)
+	}
+	return n.dataplane.Request(ctx, request, opts...)
+}
+
+func (n *noneClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if conn.GetMechanism().GetType() == MECHANISM {
+		return next.Client(ctx).Close(ctx, conn, opts...)
+	}
+	return n.dataplane.Close(ctx, conn, opts...)
+}
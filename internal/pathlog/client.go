@@ -0,0 +1,78 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package pathlog provides a chain element logging the path of the established connections, so it is seen which
+// NSMgrs, forwarders and NSE have handled them
+package pathlog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type key struct{}
+
+type pathLogClient struct{}
+
+// NewClient - returns a client chain element logging the path segments of the connection after each successful
+// Request. The path is logged at INFO level when it differs from the one logged before for the connection, e.g. on
+// the first request or after heal, and at DEBUG level on the refreshes keeping it.
+func NewClient() networkservice.NetworkServiceClient {
+	return &pathLogClient{}
+}
+
+func (c *pathLogClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	path := formatPath(conn.GetPath())
+	logger := log.FromContext(ctx).WithField("id", conn.GetId())
+	if prev, ok := metadata.Map(ctx, true).Load(key{}); ok && prev.(string) == path {
+		logger.Debugf("connection path: %s", path)
+		return conn, nil
+	}
+	metadata.Map(ctx, true).Store(key{}, path)
+	logger.Infof("connection path: %s", path)
+	return conn, nil
+}
+
+func (c *pathLogClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	metadata.Map(ctx, true).Delete(key{})
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+// formatPath - returns the segments of path in order with their names and connection IDs, the tokens are left out
+func formatPath(path *networkservice.Path) string {
+	segments := make([]string, 0, len(path.GetPathSegments()))
+	for i, segment := range path.GetPathSegments() {
+		segments = append(segments, fmt.Sprintf("[%d] %s (id: %s)", i, segment.GetName(), segment.GetId()))
+	}
+	return strings.Join(segments, " -> ")
+}
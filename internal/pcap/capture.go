@@ -0,0 +1,176 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package pcap provides a chain element capturing the packets of the client interfaces with VPP to pcap files for
+// debugging
+package pcap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/statusfile"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/vppinit"
+
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+// vppDir - VPP writes the pcap files to this directory only
+const vppDir = "/tmp"
+
+// Capture - capture of the packets of one client interface at a time, VPP supports only one pcap trace. Shared by
+// the chains of all NSMgrs and VPP instances.
+type Capture struct {
+	dir        string
+	maxPackets int
+	duration   time.Duration
+	enabled    func(connectionID string) bool
+
+	mu        sync.Mutex
+	connID    string
+	swIfIndex interface_types.InterfaceIndex
+	vppConn   api.Connection
+	timer     *time.Timer
+}
+
+// NewCapture - creates a Capture of up to maxPackets packets of the connections enabled returns true for, each
+// capture is stopped after duration or when the connection is closed and is written to dir
+func NewCapture(dir string, maxPackets int, duration time.Duration, enabled func(connectionID string) bool) *Capture {
+	return &Capture{
+		dir:        dir,
+		maxPackets: maxPackets,
+		duration:   duration,
+		enabled:    enabled,
+	}
+}
+
+// start - starts the capture of the connection with the id on swIfIndex unless it is already running. The capture is
+// started again if the interface has changed, e.g. after a heal.
+func (c *Capture) start(ctx context.Context, vppConn api.Connection, connID string, swIfIndex interface_types.InterfaceIndex) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	logger := log.FromContext(ctx).WithField("pcap", "Capture")
+	if c.connID == connID && c.swIfIndex == swIfIndex {
+		return
+	}
+	if c.connID != "" && c.connID != connID {
+		logger.Warnf("capture of connection %s is in progress, connection %s is not captured", c.connID, connID)
+		return
+	}
+	if c.connID == connID {
+		c.stopLocked(ctx)
+	}
+
+	name, err := statusfile.InterfaceName(ctx, vppConn, swIfIndex)
+	if err != nil {
+		logger.Errorf("failed to start capture of connection %s: %s", connID, err.Error())
+		return
+	}
+	cmd := fmt.Sprintf("pcap trace rx tx max %d intfc %s file %s", c.maxPackets, name, vppFile(connID))
+	if _, err = vppinit.Output(ctx, vppConn, cmd); err != nil {
+		logger.Errorf("failed to start capture of connection %s: %s", connID, err.Error())
+		return
+	}
+	c.connID, c.swIfIndex, c.vppConn = connID, swIfIndex, vppConn
+	logger.Infof("capture of connection %s on interface %s is started", connID, name)
+
+	if c.duration > 0 {
+		postponeCtxFunc := postpone.ContextWithValues(ctx)
+		c.timer = time.AfterFunc(c.duration, func() {
+			stopCtx, cancelStop := postponeCtxFunc()
+			defer cancelStop()
+			c.stop(stopCtx, connID)
+		})
+	}
+}
+
+// stop - stops the capture of the connection with the id if it is running and writes it to the directory
+func (c *Capture) stop(ctx context.Context, connID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.connID == connID {
+		c.stopLocked(ctx)
+	}
+}
+
+func (c *Capture) stopLocked(ctx context.Context) {
+	logger := log.FromContext(ctx).WithField("pcap", "Capture")
+	connID := c.connID
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+	vppConn := c.vppConn
+	c.connID, c.swIfIndex, c.vppConn, c.timer = "", 0, nil, nil
+
+	// VPP writes the captured packets to the file when the trace is stopped or the max number of packets is captured
+	if _, err := vppinit.Output(ctx, vppConn, "pcap trace off"); err != nil {
+		logger.Errorf("failed to stop capture of connection %s: %s", connID, err.Error())
+		return
+	}
+	path := filepath.Join(c.dir, fmt.Sprintf("%s-%s.pcap", connID, time.Now().UTC().Format("20060102T150405Z")))
+	if err := moveFile(filepath.Join(vppDir, vppFile(connID)), path); err != nil {
+		if os.IsNotExist(errors.Cause(err)) {
+			logger.Infof("capture of connection %s is stopped, no packets are captured", connID)
+			return
+		}
+		logger.Errorf("failed to write capture of connection %s: %s", connID, err.Error())
+		return
+	}
+	logger.Infof("capture of connection %s is stopped and written to %s", connID, path)
+}
+
+func vppFile(connID string) string {
+	return connID + ".pcap"
+}
+
+// moveFile - moves the file from src to dst, copies it if they are on different filesystems
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil || os.IsNotExist(err) {
+		return err
+	}
+	in, err := os.Open(filepath.Clean(src))
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", src)
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.Create(filepath.Clean(dst))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s", dst)
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return errors.Wrapf(err, "failed to copy %s to %s", src, dst)
+	}
+	if err = out.Close(); err != nil {
+		return errors.Wrapf(err, "failed to write %s", dst)
+	}
+	return os.Remove(src)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package pcap
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.fd.io/govpp/api"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+type pcapClient struct {
+	vppConn api.Connection
+	capture *Capture
+}
+
+// NewClient - returns a client chain element starting the capture of the client interface of the connections the
+// capture is enabled for after Request and stopping it on Close.
+// Should be placed before the mechanism chain elements, so the interface is already created when Request returns and
+// still exists when the capture is stopped.
+func NewClient(vppConn api.Connection, capture *Capture) networkservice.NetworkServiceClient {
+	return &pcapClient{
+		vppConn: vppConn,
+		capture: capture,
+	}
+}
+
+func (c *pcapClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if !c.capture.enabled(conn.GetId()) {
+		return conn, nil
+	}
+	if swIfIndex, ok := ifindex.Load(ctx, true); ok {
+		c.capture.start(ctx, c.vppConn, conn.GetId(), swIfIndex)
+	}
+	return conn, nil
+}
+
+func (c *pcapClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.capture.stop(ctx, conn.GetId())
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package phases provides measuring the phases of the establishment of a connection: the dial of NSMgr, the request
+// to NSMgr and the configuration of the VPP interface
+package phases
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/metrics"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+// Phases of the connection establishment
+const (
+	Monitor   = "monitor"
+	Dial      = "dial"
+	Request   = "request"
+	Interface = "interface"
+)
+
+// PhaseKey - attribute key of the phase in the metrics
+const PhaseKey = attribute.Key("nsm.phase")
+
+type timingsKey struct{}
+
+// Timings - the times the request passes the chain elements of this package at. If the request is retried, the dial
+// of the first try and the interface of the last one count.
+type Timings struct {
+	mu          sync.Mutex
+	start       time.Time
+	firstDialed time.Time
+	dialed      time.Time
+	sent        time.Time
+	received    time.Time
+}
+
+// WithTimings - returns ctx with new Timings started now, the chain elements mark the request with it
+func WithTimings(ctx context.Context) (context.Context, *Timings) {
+	t := &Timings{
+		start: time.Now(),
+	}
+	return context.WithValue(ctx, timingsKey{}, t), t
+}
+
+// Durations - returns the durations of the phases from the start until now: Dial is the time to reach the chain
+// elements after the dial of NSMgr, Interface the time spent in the VPP chain elements before and after the request
+// to NSMgr, and Request the rest, including the failed tries. The phases the request hasn't reached are not set.
+func (t *Timings) Durations() map[string]time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	total := time.Since(t.start)
+	durations := make(map[string]time.Duration)
+	if !t.firstDialed.IsZero() {
+		durations[Dial] = t.firstDialed.Sub(t.start)
+	}
+	if !t.dialed.IsZero() && !t.sent.IsZero() && !t.received.IsZero() {
+		durations[Interface] = t.sent.Sub(t.dialed) + time.Since(t.received)
+	}
+	durations[Request] = total - durations[Dial] - durations[Interface]
+	return durations
+}
+
+func (t *Timings) mark(mark *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	*mark = time.Now()
+}
+
+func (t *Timings) markDialed() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.dialed = time.Now()
+	if t.firstDialed.IsZero() {
+		t.firstDialed = t.dialed
+	}
+}
+
+func fromContext(ctx context.Context) *Timings {
+	t, _ := ctx.Value(timingsKey{}).(*Timings)
+	return t
+}
+
+type dialedClient struct{}
+
+// NewDialedClient - returns a client chain element marking the time the request reaches it, should be placed first
+// after the dial of NSMgr. Does nothing for the requests with no Timings, e.g. the refreshes and the heals.
+func NewDialedClient() networkservice.NetworkServiceClient {
+	return &dialedClient{}
+}
+
+func (c *dialedClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	if t := fromContext(ctx); t != nil {
+		t.markDialed()
+	}
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (c *dialedClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+type nsmgrClient struct{}
+
+// NewNSMgrClient - returns a client chain element marking the times the request is sent to NSMgr and the response is
+// received, should be placed after the VPP chain elements. Does nothing for the requests with no Timings.
+func NewNSMgrClient() networkservice.NetworkServiceClient {
+	return &nsmgrClient{}
+}
+
+func (c *nsmgrClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	t := fromContext(ctx)
+	if t == nil {
+		return next.Client(ctx).Request(ctx, request, opts...)
+	}
+	t.mark(&t.sent)
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	t.mark(&t.received)
+	return conn, err
+}
+
+func (c *nsmgrClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+var (
+	histogramOnce sync.Once
+	histogram     metric.Float64Histogram
+)
+
+// Record - records durations in the nsc_connection_phase_duration_seconds histogram labeled by the network service, the
+// tenant if it is set and the phase
+func Record(ctx context.Context, networkService, tenant string, durations map[string]time.Duration) {
+	// Created on the first use, so it is created after the meter provider is initialized
+	histogramOnce.Do(func() {
+		histogram = metrics.Float64Histogram("nsc_connection_phase_duration_seconds",
+			"Duration of the phases of the establishment of the client connections", "s")
+	})
+	attrs := []attribute.KeyValue{metrics.NetworkServiceKey.String(networkService)}
+	if tenant != "" {
+		attrs = append(attrs, metrics.TenantKey.String(tenant))
+	}
+	for phase, duration := range durations {
+		histogram.Record(ctx, duration.Seconds(), metric.WithAttributes(append(attrs, PhaseKey.String(phase))...))
+	}
+}
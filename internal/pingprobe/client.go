@@ -0,0 +1,184 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package pingprobe provides a chain element checking that the packets flow over the established connections by
+// pinging a peer address through the client interface with VPP
+package pingprobe
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/govpp/binapi/ping"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+// pingInterval - interval between the echo requests of a probe
+const pingInterval = time.Second
+
+// Probe - ICMP probe of a connection
+type Probe struct {
+	// Target - address to ping, the NSE address of the connection if nil
+	Target net.IP
+	// Timeout - time to get a reply in
+	Timeout time.Duration
+}
+
+type pingProbeClient struct {
+	vppConn api.Connection
+	probes  func(connectionID string) *Probe
+	// mu - serializes the echo requests, ping.PingFinishedEvent has no address or interface to tell whose it is
+	mu sync.Mutex
+}
+
+// NewClient - returns a client chain element pinging the target of the probe returned by probes for the connection ID
+// through the client interface after the first Request of the connection. If no reply is received in the probe
+// timeout, the connection is closed and Request returns an error, so it is retried. The connections probes returns
+// nil for are not pinged.
+// Should be placed before the mechanism and connectioncontext chain elements, so the interface is configured when
+// Request returns.
+func NewClient(vppConn api.Connection, probes func(connectionID string) *Probe) networkservice.NetworkServiceClient {
+	return &pingProbeClient{
+		vppConn: vppConn,
+		probes:  probes,
+	}
+}
+
+func (c *pingProbeClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	probe := c.probes(request.GetConnection().GetId())
+	if probe == nil {
+		return next.Client(ctx).Request(ctx, request, opts...)
+	}
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	swIfIndex, ok := ifindex.Load(ctx, true)
+	if !ok || probed(ctx) {
+		return conn, nil
+	}
+
+	target := probe.Target
+	if target == nil {
+		if dstIPNets := conn.GetContext().GetIpContext().GetDstIPNets(); len(dstIPNets) > 0 {
+			target = dstIPNets[0].IP
+		}
+	}
+	if target == nil {
+		err = errors.Errorf("NSE has not provided an address to ping for connection %s", conn.GetId())
+	} else {
+		err = c.waitForReply(ctx, swIfIndex, target, probe.Timeout)
+	}
+	if err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+		if _, closeErr := next.Client(ctx).Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+		return nil, err
+	}
+	storeProbed(ctx)
+	return conn, nil
+}
+
+func (c *pingProbeClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func (c *pingProbeClient) waitForReply(ctx context.Context, swIfIndex interface_types.InterfaceIndex, target net.IP, timeout time.Duration) error {
+	logger := log.FromContext(ctx).WithField("swIfIndex", swIfIndex).WithField("target", target)
+
+	probeCtx, cancelProbe := context.WithTimeout(ctx, timeout)
+	defer cancelProbe()
+
+	now := time.Now()
+	for attempt := 1; ; attempt++ {
+		replied, err := c.sendEchoRequest(probeCtx, swIfIndex, target)
+		if replied {
+			logger.WithField("duration", time.Since(now)).Infof("ping is replied after %d attempts", attempt)
+			return nil
+		}
+		if err != nil && probeCtx.Err() == nil {
+			return err
+		}
+
+		select {
+		case <-probeCtx.Done():
+			if ctx.Err() != nil {
+				return errors.Wrap(ctx.Err(), "provided context is done")
+			}
+			logger.Warnf("ping is not replied in %s after %d attempts", timeout, attempt)
+			return errors.Errorf("no ping reply from %s in %s", target, timeout)
+		case <-time.After(pingInterval):
+		}
+	}
+}
+
+// sendEchoRequest - sends an echo request to target through swIfIndex, returns true if it is replied. Each watcher gets
+// the events of all echo requests, so only one is sent at a time.
+func (c *pingProbeClient) sendEchoRequest(ctx context.Context, swIfIndex interface_types.InterfaceIndex, target net.IP) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	watcher, err := c.vppConn.WatchEvent(ctx, &ping.PingFinishedEvent{})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to watch ping.PingFinishedEvent")
+	}
+	defer watcher.Close()
+
+	now := time.Now()
+	if _, err = ping.NewServiceClient(c.vppConn).WantPingFinishedEvents(ctx, &ping.WantPingFinishedEvents{
+		Address:   types.ToVppAddress(target),
+		SwIfIndex: swIfIndex,
+		Repeat:    1,
+		Interval:  pingInterval.Seconds(),
+	}); err != nil {
+		return false, errors.Wrap(err, "vppapi WantPingFinishedEvents returned error")
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("target", target).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "WantPingFinishedEvents").Debug("completed")
+
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	case rawMsg := <-watcher.Events():
+		msg, ok := rawMsg.(*ping.PingFinishedEvent)
+		return ok && msg.ReplyCount > 0, nil
+	}
+}
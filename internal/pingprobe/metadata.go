@@ -0,0 +1,36 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pingprobe
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type key struct{}
+
+// storeProbed sets that the connection has passed the probe, stored in per Connection.Id metadata
+func storeProbed(ctx context.Context) {
+	metadata.Map(ctx, true).Store(key{}, struct{}{})
+}
+
+// probed returns true if the connection has passed the probe, stored in per Connection.Id metadata
+func probed(ctx context.Context) bool {
+	_, ok := metadata.Map(ctx, true).Load(key{})
+	return ok
+}
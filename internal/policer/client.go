@@ -0,0 +1,207 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package policer provides a chain element requesting bandwidth for the connections, limiting the traffic sent
+// by the client interfaces to it and marking it with DSCP
+package policer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/govpp/binapi/policer"
+	"github.com/networkservicemesh/govpp/binapi/policer_types"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+const (
+	// BandwidthKey - key of the requested bandwidth in Mbps in the extra context of the connection
+	BandwidthKey = "bandwidth-mbps"
+	// DSCPLabel - NSURL query parameter overriding the DSCP value marked on the traffic of the connection, it is not
+	// sent as a label
+	DSCPLabel = "dscp"
+	// NoDSCP - DSCP value disabling the marking
+	NoDSCP = -1
+)
+
+type policerClient struct {
+	vppConn       api.Connection
+	bandwidthMbps uint32
+	dscps         func(connectionID string) int
+}
+
+// NewClient - returns a client chain element putting bandwidthMbps to the extra context of the requests, so the
+// NSE and the forwarder can apply shaping, and policing the traffic sent by the client interface in VPP to it.
+// The traffic is marked with the DSCP value dscps returns for the connection ID, no bandwidth limit is applied if
+// bandwidthMbps is 0 and no marking is done if the DSCP value is NoDSCP.
+// Should be placed before the mechanism chain elements, so the interface is already created when Request returns.
+func NewClient(vppConn api.Connection, bandwidthMbps uint32, dscps func(connectionID string) int) networkservice.NetworkServiceClient {
+	return &policerClient{
+		vppConn:       vppConn,
+		bandwidthMbps: bandwidthMbps,
+		dscps:         dscps,
+	}
+}
+
+func (c *policerClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	dscp := c.dscps(request.GetConnection().GetId())
+	if c.bandwidthMbps == 0 && dscp == NoDSCP {
+		return next.Client(ctx).Request(ctx, request, opts...)
+	}
+
+	if c.bandwidthMbps > 0 {
+		if request.GetConnection().GetContext() == nil {
+			request.GetConnection().Context = &networkservice.ConnectionContext{}
+		}
+		if request.GetConnection().GetContext().GetExtraContext() == nil {
+			request.GetConnection().GetContext().ExtraContext = make(map[string]string)
+		}
+		request.GetConnection().GetContext().GetExtraContext()[BandwidthKey] = strconv.FormatUint(uint64(c.bandwidthMbps), 10)
+	}
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	swIfIndex, ok := ifindex.Load(ctx, true)
+	if !ok || loaded(ctx) {
+		return conn, nil
+	}
+	policerIndex, err := addPolicer(ctx, c.vppConn, swIfIndex, c.bandwidthMbps, dscp)
+	if err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+		if _, closeErr := next.Client(ctx).Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+		return nil, err
+	}
+	store(ctx, policerIndex)
+	return conn, nil
+}
+
+func (c *policerClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if policerIndex, ok := loadAndDelete(ctx); ok {
+		if swIfIndex, loaded := ifindex.Load(ctx, true); loaded {
+			if err := delPolicer(ctx, c.vppConn, swIfIndex, policerIndex); err != nil {
+				log.FromContext(ctx).Errorf("failed to delete policer: %s", err.Error())
+			}
+		}
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func policerName(swIfIndex interface_types.InterfaceIndex) string {
+	return fmt.Sprintf("nsc-%d", swIfIndex)
+}
+
+func addPolicer(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, bandwidthMbps uint32, dscp int) (uint32, error) {
+	// With no bandwidth limit the policer only marks the traffic, so it never exceeds the rate
+	cir := uint32(math.MaxUint32)
+	exceedAction := policer_types.Sse2QosAction{Type: policer_types.SSE2_QOS_ACTION_API_TRANSMIT}
+	if bandwidthMbps > 0 {
+		cir = bandwidthMbps * 1000
+		exceedAction = policer_types.Sse2QosAction{Type: policer_types.SSE2_QOS_ACTION_API_DROP}
+	}
+	conformAction := policer_types.Sse2QosAction{Type: policer_types.SSE2_QOS_ACTION_API_TRANSMIT}
+	if dscp != NoDSCP {
+		conformAction = policer_types.Sse2QosAction{Type: policer_types.SSE2_QOS_ACTION_API_MARK_AND_TRANSMIT, Dscp: uint8(dscp)}
+		if bandwidthMbps == 0 {
+			exceedAction = conformAction
+		}
+	}
+
+	now := time.Now()
+	reply, err := policer.NewServiceClient(vppConn).PolicerAdd(ctx, &policer.PolicerAdd{
+		Name: policerName(swIfIndex),
+		Infos: policer_types.PolicerConfig{
+			Cir: cir,
+			// Burst of 100ms of traffic at the committed rate
+			Cb:            uint64(cir) * 1000 / 8 / 10,
+			RateType:      policer_types.SSE2_QOS_RATE_API_KBPS,
+			RoundType:     policer_types.SSE2_QOS_ROUND_API_TO_CLOSEST,
+			Type:          policer_types.SSE2_QOS_POLICER_TYPE_API_1R2C,
+			ConformAction: conformAction,
+			ExceedAction:  exceedAction,
+			ViolateAction: exceedAction,
+		},
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "vppapi PolicerAdd returned error")
+	}
+	log.FromContext(ctx).
+		WithField("name", policerName(swIfIndex)).
+		WithField("cir", cir).
+		WithField("dscp", dscp).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "PolicerAdd").Debug("completed")
+
+	now = time.Now()
+	if _, err = policer.NewServiceClient(vppConn).PolicerOutput(ctx, &policer.PolicerOutput{
+		Name:      policerName(swIfIndex),
+		SwIfIndex: swIfIndex,
+		Apply:     true,
+	}); err != nil {
+		_, _ = policer.NewServiceClient(vppConn).PolicerDel(ctx, &policer.PolicerDel{PolicerIndex: reply.PolicerIndex})
+		return 0, errors.Wrap(err, "vppapi PolicerOutput returned error")
+	}
+	log.FromContext(ctx).
+		WithField("name", policerName(swIfIndex)).
+		WithField("swIfIndex", swIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "PolicerOutput").Debug("completed")
+	return reply.PolicerIndex, nil
+}
+
+func delPolicer(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, policerIndex uint32) error {
+	now := time.Now()
+	if _, err := policer.NewServiceClient(vppConn).PolicerOutput(ctx, &policer.PolicerOutput{
+		Name:      policerName(swIfIndex),
+		SwIfIndex: swIfIndex,
+		Apply:     false,
+	}); err != nil {
+		return errors.Wrap(err, "vppapi PolicerOutput returned error")
+	}
+	if _, err := policer.NewServiceClient(vppConn).PolicerDel(ctx, &policer.PolicerDel{
+		PolicerIndex: policerIndex,
+	}); err != nil {
+		return errors.Wrap(err, "vppapi PolicerDel returned error")
+	}
+	log.FromContext(ctx).
+		WithField("name", policerName(swIfIndex)).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "PolicerDel").Debug("completed")
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policer
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type key struct{}
+
+// store sets the index of the policer applied to the interface, stored in per Connection.Id metadata
+func store(ctx context.Context, policerIndex uint32) {
+	metadata.Map(ctx, true).Store(key{}, policerIndex)
+}
+
+// loadAndDelete returns the index of the policer applied to the interface and deletes it from per Connection.Id metadata
+func loadAndDelete(ctx context.Context) (value uint32, ok bool) {
+	rawValue, ok := metadata.Map(ctx, true).LoadAndDelete(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(uint32)
+	return value, ok
+}
+
+// loaded returns true if a policer is stored in per Connection.Id metadata
+func loaded(ctx context.Context) bool {
+	_, ok := metadata.Map(ctx, true).Load(key{})
+	return ok
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package preferredip
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type preferredIPClient struct {
+	file *File
+}
+
+// NewClient - returns a client chain element adding the preferred IP of the network service from file to the source
+// IPs of the first request of a connection as a hint for the IPAM of the NSE. If the request with the hint fails, it
+// is sent again without it. The source IP the connection gets is stored to file, so it is preferred after a restart.
+func NewClient(file *File) networkservice.NetworkServiceClient {
+	return &preferredIPClient{
+		file: file,
+	}
+}
+
+func (c *preferredIPClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	logger := log.FromContext(ctx).WithField("preferredIPClient", "Request")
+	service := request.GetConnection().GetNetworkService()
+
+	// The refreshes and the heal requests carry the IP context the connection has got
+	preferred := c.file.Get(service)
+	if preferred == "" || len(request.GetConnection().GetContext().GetIpContext().GetSrcIpAddrs()) > 0 {
+		conn, err := next.Client(ctx).Request(ctx, request, opts...)
+		if err != nil {
+			return nil, err
+		}
+		c.store(ctx, conn)
+		return conn, nil
+	}
+
+	hinted := request.Clone()
+	if hinted.GetConnection().GetContext() == nil {
+		hinted.GetConnection().Context = &networkservice.ConnectionContext{}
+	}
+	if hinted.GetConnection().GetContext().GetIpContext() == nil {
+		hinted.GetConnection().GetContext().IpContext = &networkservice.IPContext{}
+	}
+	hinted.GetConnection().GetContext().GetIpContext().SrcIpAddrs = []string{preferred}
+
+	conn, err := next.Client(ctx).Request(ctx, hinted, opts...)
+	if err != nil {
+		logger.Warnf("request with preferred IP %s has failed, requesting any IP: %s", preferred, err.Error())
+		if conn, err = next.Client(ctx).Request(ctx, request, opts...); err != nil {
+			return nil, err
+		}
+	}
+	if got := conn.GetContext().GetIpContext().GetSrcIpAddrs(); len(got) > 0 && got[0] != preferred {
+		logger.Warnf("preferred IP %s is not assigned, got %s", preferred, got[0])
+	}
+	c.store(ctx, conn)
+	return conn, nil
+}
+
+func (c *preferredIPClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func (c *preferredIPClient) store(ctx context.Context, conn *networkservice.Connection) {
+	srcIPs := conn.GetContext().GetIpContext().GetSrcIpAddrs()
+	if len(srcIPs) == 0 {
+		return
+	}
+	if err := c.file.Store(conn.GetNetworkService(), srcIPs[0]); err != nil {
+		log.FromContext(ctx).Errorf("failed to update preferred IPs file: %s", err.Error())
+	}
+}
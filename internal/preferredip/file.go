@@ -0,0 +1,77 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package preferredip provides a JSON file mapping the network services to the source IPs preferred in their requests,
+// so the connections get the same addresses across restarts
+package preferredip
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/statusfile"
+)
+
+// File - the preferred IPs stored in the file at path, e.g. {"my-service": "172.16.0.2/32"}
+type File struct {
+	path string
+
+	mu  sync.Mutex
+	ips map[string]string
+}
+
+// Load - reads the preferred IPs from the file at path, there are none if it doesn't exist yet
+func Load(path string) (*File, error) {
+	f := &File{
+		path: path,
+		ips:  make(map[string]string),
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read preferred IPs from %s", path)
+	}
+	if err = json.Unmarshal(data, &f.ips); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse preferred IPs from %s", path)
+	}
+	return f, nil
+}
+
+// Get - returns the preferred IP of the network service, empty if there is none
+func (f *File) Get(networkService string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.ips[networkService]
+}
+
+// Store - sets the preferred IP of the network service and rewrites the file if it has changed
+func (f *File) Store(networkService, ip string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.ips[networkService] == ip {
+		return nil
+	}
+	f.ips[networkService] = ip
+	return statusfile.WriteJSON(f.path, f.ips)
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package prefixcollision provides a client chain element reporting which excluded prefixes collide with the
+// addresses offered by the NSE when the excludedprefixes client rejects them
+package prefixcollision
+
+import (
+	"context"
+	"net"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/metrics"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/tenant"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Sources of the collided prefixes
+const (
+	// SourceConfigured - the prefix was in the request before the excludedprefixes client, e.g. from the excluded
+	// prefixes file
+	SourceConfigured = "configured"
+	// SourceConnections - the prefix was added by the excludedprefixes client: an address of another connection or
+	// of another awareness group
+	SourceConnections = "connections or awareness groups"
+)
+
+type configuredKey struct{}
+
+type markClient struct{}
+
+type checkClient struct {
+	collisions metric.Int64Counter
+}
+
+// NewClient - returns excludedPrefixesClient wrapped with the chain elements logging each address of the response
+// which collides with an excluded prefix and its source, and counting the rejected responses per network service and
+// tenant in the nsc_excluded_prefix_collisions counter
+func NewClient(excludedPrefixesClient networkservice.NetworkServiceClient) networkservice.NetworkServiceClient {
+	return chain.NewNetworkServiceClient(
+		&markClient{},
+		excludedPrefixesClient,
+		&checkClient{
+			collisions: metrics.Int64Counter("nsc_excluded_prefix_collisions",
+				"number of the NSE responses rejected because of the addresses colliding with the excluded prefixes"),
+		},
+	)
+}
+
+func (c *markClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	configured := append([]string(nil), request.GetConnection().GetContext().GetIpContext().GetExcludedPrefixes()...)
+	return next.Client(ctx).Request(context.WithValue(ctx, configuredKey{}, configured), request, opts...)
+}
+
+func (c *markClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func (c *checkClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	// The excludedprefixes client sets all the prefixes it checks the response against in the request
+	excluded := append([]string(nil), request.GetConnection().GetContext().GetIpContext().GetExcludedPrefixes()...)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	configured, _ := ctx.Value(configuredKey{}).([]string)
+	ipCtx := conn.GetContext().GetIpContext()
+	collided := false
+	for _, addr := range append(ipCtx.GetSrcIpAddrs(), ipCtx.GetDstIpAddrs()...) {
+		prefix := collision(addr, excluded)
+		if prefix == "" {
+			continue
+		}
+		collided = true
+		source := SourceConnections
+		if contains(configured, prefix) {
+			source = SourceConfigured
+		}
+		log.FromContext(ctx).
+			WithField("networkService", conn.GetNetworkService()).
+			WithField("nse", conn.GetNetworkServiceEndpointName()).
+			Warnf("address %s offered by the NSE collides with the excluded prefix %s from %s", addr, prefix, source)
+	}
+	if collided {
+		attrs := []attribute.KeyValue{metrics.NetworkServiceKey.String(conn.GetNetworkService())}
+		if t := tenant.FromContext(ctx); t != "" {
+			attrs = append(attrs, metrics.TenantKey.String(t))
+		}
+		c.collisions.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+	return conn, nil
+}
+
+func (c *checkClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+// collision - returns the prefix of excluded addr collides with, the same way the excludedprefixes client checks it:
+// only the host prefixes are taken into account
+func collision(addr string, excluded []string) string {
+	ip, _, err := net.ParseCIDR(addr)
+	if err != nil {
+		return ""
+	}
+	for _, prefix := range excluded {
+		_, ipNet, err := net.ParseCIDR(prefix)
+		if err != nil {
+			continue
+		}
+		if ones, bits := ipNet.Mask.Size(); ones == bits && ipNet.Contains(ip) {
+			return prefix
+		}
+	}
+	return ""
+}
+
+func contains(prefixes []string, prefix string) bool {
+	for _, p := range prefixes {
+		if p == prefix {
+			return true
+		}
+	}
+	return false
+}
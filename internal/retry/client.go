@@ -0,0 +1,210 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retry provides a networkservice.NetworkServiceClient wrapper retrying requests and closes with
+// exponential backoff and jitter
+package retry
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type retryClient struct {
+	tryTimeout  time.Duration
+	tryTimeouts func(connectionID string) (time.Duration, bool)
+	interval    time.Duration
+	maxInterval time.Duration
+	multiplier  float64
+	jitter      float64
+	maxRetries  int
+	retriesOf   func(connectionID string) (int, bool)
+	client      networkservice.NetworkServiceClient
+}
+
+// Option - configures retry client
+type Option func(*retryClient)
+
+// WithTryTimeout - sets timeout for each try of the request and close operations
+func WithTryTimeout(tryTimeout time.Duration) Option {
+	return func(rc *retryClient) {
+		rc.tryTimeout = tryTimeout
+	}
+}
+
+// WithTryTimeouts - sets timeout for each try of the request and close operations of the connections tryTimeouts
+// returns a timeout for, overriding the one set by WithTryTimeout for them. tryTimeouts is called on each operation,
+// so it may return different timeouts over time.
+func WithTryTimeouts(tryTimeouts func(connectionID string) (time.Duration, bool)) Option {
+	return func(rc *retryClient) {
+		rc.tryTimeouts = tryTimeouts
+	}
+}
+
+// WithInterval - sets delay before the first retry
+func WithInterval(interval time.Duration) Option {
+	return func(rc *retryClient) {
+		rc.interval = interval
+	}
+}
+
+// WithMaxInterval - sets the upper bound of the delay between retries
+func WithMaxInterval(maxInterval time.Duration) Option {
+	return func(rc *retryClient) {
+		rc.maxInterval = maxInterval
+	}
+}
+
+// WithMultiplier - sets the factor the delay is multiplied by after each failed try
+func WithMultiplier(multiplier float64) Option {
+	return func(rc *retryClient) {
+		rc.multiplier = multiplier
+	}
+}
+
+// WithJitter - sets the fraction of the delay it is randomly changed by, in [0, 1]
+func WithJitter(jitter float64) Option {
+	return func(rc *retryClient) {
+		rc.jitter = jitter
+	}
+}
+
+// WithMaxRetries - sets the maximum number of retries after the first try, unlimited if 0
+func WithMaxRetries(maxRetries int) Option {
+	return func(rc *retryClient) {
+		rc.maxRetries = maxRetries
+	}
+}
+
+// WithMaxRetriesOf - sets the maximum number of retries after the first try of the connections maxRetriesOf returns
+// a number for, overriding the one set by WithMaxRetries for them, unlimited if 0. maxRetriesOf is called on each
+// operation, so it may return different numbers over time.
+func WithMaxRetriesOf(maxRetriesOf func(connectionID string) (int, bool)) Option {
+	return func(rc *retryClient) {
+		rc.retriesOf = maxRetriesOf
+	}
+}
+
+// NewClient - returns a client retrying requests and closes of the client until ctx is done or the retries are over
+func NewClient(client networkservice.NetworkServiceClient, opts ...Option) networkservice.NetworkServiceClient {
+	var result = &retryClient{
+		tryTimeout:  time.Second * 15,
+		interval:    time.Millisecond * 200,
+		maxInterval: time.Second * 30,
+		multiplier:  2,
+		client:      client,
+	}
+
+	for _, opt := range opts {
+		opt(result)
+	}
+
+	return result
+}
+
+func (r *retryClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	var resp *networkservice.Connection
+	err := r.retry(ctx, "Request", r.tryTimeoutOf(request.GetConnection()), r.maxRetriesOfConn(request.GetConnection()), func(tryCtx context.Context) (err error) {
+		resp, err = r.client.Request(tryCtx, request.Clone(), opts...)
+		return err
+	})
+	return resp, err
+}
+
+func (r *retryClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	var resp *empty.Empty
+	err := r.retry(ctx, "Close", r.tryTimeoutOf(conn), r.maxRetriesOfConn(conn), func(tryCtx context.Context) (err error) {
+		resp, err = r.client.Close(tryCtx, conn.Clone(), opts...)
+		return err
+	})
+	return resp, err
+}
+
+func (r *retryClient) tryTimeoutOf(conn *networkservice.Connection) time.Duration {
+	if r.tryTimeouts == nil {
+		return r.tryTimeout
+	}
+	if tryTimeout, ok := r.tryTimeouts(conn.GetId()); ok {
+		return tryTimeout
+	}
+	return r.tryTimeout
+}
+
+func (r *retryClient) maxRetriesOfConn(conn *networkservice.Connection) int {
+	if r.retriesOf == nil {
+		return r.maxRetries
+	}
+	if maxRetries, ok := r.retriesOf(conn.GetId()); ok {
+		return maxRetries
+	}
+	return r.maxRetries
+}
+
+func (r *retryClient) retry(ctx context.Context, method string, tryTimeout time.Duration, maxRetries int, try func(tryCtx context.Context) error) error {
+	logger := log.FromContext(ctx).WithField("retryClient", method)
+	c := clock.FromContext(ctx)
+
+	interval := r.interval
+	for attempt := 0; ; attempt++ {
+		tryCtx, cancel := c.WithTimeout(ctx, tryTimeout)
+		err := try(tryCtx)
+		cancel()
+
+		if err == nil {
+			return nil
+		}
+		if maxRetries > 0 && attempt >= maxRetries {
+			logger.Errorf("try attempt %d has failed, no retries left: %v", attempt+1, err.Error())
+			return err
+		}
+
+		delay := r.jittered(interval)
+		logger.Errorf("try attempt %d has failed, retrying in %s: %v", attempt+1, delay, err.Error())
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.After(delay):
+		}
+
+		interval = r.nextInterval(interval)
+	}
+}
+
+// nextInterval - returns the delay before the retry following the one delayed by interval, before the jitter
+func (r *retryClient) nextInterval(interval time.Duration) time.Duration {
+	interval = time.Duration(float64(interval) * r.multiplier)
+	if r.maxInterval > 0 && interval > r.maxInterval {
+		return r.maxInterval
+	}
+	return interval
+}
+
+func (r *retryClient) jittered(interval time.Duration) time.Duration {
+	if r.jitter <= 0 {
+		return interval
+	}
+	// #nosec G404 - jitter doesn't need a cryptographically secure random
+	return time.Duration(float64(interval) * (1 + r.jitter*(2*rand.Float64()-1)))
+}
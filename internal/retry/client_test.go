@@ -0,0 +1,166 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// failingClient - fails all requests and closes, counts the tries
+type failingClient struct {
+	tries int
+}
+
+func (c *failingClient) Request(context.Context, *networkservice.NetworkServiceRequest, ...grpc.CallOption) (*networkservice.Connection, error) {
+	c.tries++
+	return nil, errors.New("failed")
+}
+
+func (c *failingClient) Close(context.Context, *networkservice.Connection, ...grpc.CallOption) (*empty.Empty, error) {
+	c.tries++
+	return nil, errors.New("failed")
+}
+
+func TestNextInterval(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		interval    time.Duration
+		maxInterval time.Duration
+		multiplier  float64
+		want        []time.Duration
+	}{
+		{
+			name:        "doubled",
+			interval:    100 * time.Millisecond,
+			maxInterval: time.Minute,
+			multiplier:  2,
+			want:        []time.Duration{200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond},
+		},
+		{
+			name:        "fractional multiplier",
+			interval:    time.Second,
+			maxInterval: time.Minute,
+			multiplier:  1.5,
+			want:        []time.Duration{1500 * time.Millisecond, 2250 * time.Millisecond, 3375 * time.Millisecond},
+		},
+		{
+			name:        "capped at max interval",
+			interval:    time.Second,
+			maxInterval: 5 * time.Second,
+			multiplier:  2,
+			want:        []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second},
+		},
+		{
+			name:       "no max interval",
+			interval:   time.Second,
+			multiplier: 10,
+			want:       []time.Duration{10 * time.Second, 100 * time.Second, 1000 * time.Second},
+		},
+		{
+			name:        "constant",
+			interval:    time.Second,
+			maxInterval: time.Minute,
+			multiplier:  1,
+			want:        []time.Duration{time.Second, time.Second},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &retryClient{maxInterval: tc.maxInterval, multiplier: tc.multiplier}
+			interval := tc.interval
+			for i, want := range tc.want {
+				if interval = r.nextInterval(interval); interval != want {
+					t.Fatalf("expected interval %s after %d retries, got %s", want, i+1, interval)
+				}
+			}
+		})
+	}
+}
+
+func TestJittered(t *testing.T) {
+	const interval = time.Second
+	for _, jitter := range []float64{0, 0.1, 0.5, 1} {
+		r := &retryClient{jitter: jitter}
+		lower := time.Duration(float64(interval) * (1 - jitter))
+		upper := time.Duration(float64(interval) * (1 + jitter))
+		for i := 0; i < 1000; i++ {
+			if delay := r.jittered(interval); delay < lower || delay > upper {
+				t.Fatalf("expected delay in [%s, %s] with jitter %v, got %s", lower, upper, jitter, delay)
+			}
+		}
+	}
+	if delay := (&retryClient{}).jittered(interval); delay != interval {
+		t.Fatalf("expected delay %s with no jitter, got %s", interval, delay)
+	}
+}
+
+func TestMaxRetries(t *testing.T) {
+	maxRetriesOf := func(connectionID string) (int, bool) {
+		switch connectionID {
+		case "nsc-1":
+			return 1, true
+		case "nsc-5":
+			return 5, true
+		}
+		return 0, false
+	}
+	for _, tc := range []struct {
+		name         string
+		id           string
+		maxRetries   int
+		maxRetriesOf func(connectionID string) (int, bool)
+		wantTries    int
+	}{
+		{name: "no retries set", id: "nsc-0", maxRetries: 2, wantTries: 3},
+		{name: "max retries of other connections", id: "nsc-0", maxRetries: 2, maxRetriesOf: maxRetriesOf, wantTries: 3},
+		{name: "fewer max retries of the connection", id: "nsc-1", maxRetries: 2, maxRetriesOf: maxRetriesOf, wantTries: 2},
+		{name: "more max retries of the connection", id: "nsc-5", maxRetries: 2, maxRetriesOf: maxRetriesOf, wantTries: 6},
+		{name: "max retries of the connection only", id: "nsc-1", maxRetriesOf: maxRetriesOf, wantTries: 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			next := &failingClient{}
+			opts := []Option{WithInterval(time.Nanosecond), WithMaxRetries(tc.maxRetries)}
+			if tc.maxRetriesOf != nil {
+				opts = append(opts, WithMaxRetriesOf(tc.maxRetriesOf))
+			}
+			client := NewClient(next, opts...)
+			conn := &networkservice.Connection{Id: tc.id}
+
+			if _, err := client.Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: conn}); err == nil {
+				t.Fatal("expected the request to fail")
+			}
+			if next.tries != tc.wantTries {
+				t.Fatalf("expected %d request tries, got %d", tc.wantTries, next.tries)
+			}
+
+			next.tries = 0
+			if _, err := client.Close(context.Background(), conn); err == nil {
+				t.Fatal("expected the close to fail")
+			}
+			if next.tries != tc.wantTries {
+				t.Fatalf("expected %d close tries, got %d", tc.wantTries, next.tries)
+			}
+		})
+	}
+}
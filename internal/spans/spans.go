@@ -0,0 +1,56 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package spans provides OpenTelemetry spans for the application level operations
+package spans
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
+)
+
+const tracerName = "github.com/networkservicemesh/cmd-nsc-vpp"
+
+// Attribute keys of the spans
+const (
+	NetworkServiceKey = attribute.Key("nsm.network_service")
+	ConnectionIDKey   = attribute.Key("nsm.connection_id")
+	MechanismKey      = attribute.Key("nsm.mechanism")
+	TenantKey         = attribute.Key("nsm.tenant")
+)
+
+// Start - starts a span as a child of the span in ctx if OpenTelemetry is enabled, otherwise returns a no-op span
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !opentelemetry.IsEnabled() {
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End - records err if it is not nil and ends the span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package srv provides dialing NSMgr discovered through a DNS SRV record
+package srv
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Scheme - URL scheme of the SRV addresses: srv://NAME, e.g. srv://_nsmgr._tcp.nsm-system.svc.cluster.local
+const Scheme = "srv"
+
+// Dialer - dials the target of the SRV record, the record is resolved again if the dial of the current target fails
+type Dialer struct {
+	name string
+
+	mu     sync.Mutex
+	target string
+}
+
+// NewDialer - creates a Dialer for the SRV record with the name
+func NewDialer(name string) *Dialer {
+	return &Dialer{
+		name: name,
+	}
+}
+
+// Resolve - looks the SRV record up and returns host:port of its target with the lowest priority, the target is
+// dialed until the next Resolve
+func (d *Dialer) Resolve(ctx context.Context) (string, error) {
+	_, addrs, err := net.DefaultResolver.LookupSRV(ctx, "", "", d.name)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to resolve SRV record %s", d.name)
+	}
+	if len(addrs) == 0 {
+		return "", errors.Errorf("SRV record %s has no targets", d.name)
+	}
+	// LookupSRV sorts the targets by priority and randomizes them by weight within a priority
+	target := net.JoinHostPort(strings.TrimSuffix(addrs[0].Target, "."), strconv.Itoa(int(addrs[0].Port)))
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if target != d.target {
+		log.FromContext(ctx).Infof("SRV record %s is resolved to %s", d.name, target)
+		d.target = target
+	}
+	return target, nil
+}
+
+// ContextDialer - returns a dialer for grpc.WithContextDialer dialing the SRV target for the srv://NAME address of
+// the Dialer and passing the other addresses to next
+func (d *Dialer) ContextDialer(next func(ctx context.Context, addr string) (net.Conn, error)) func(ctx context.Context, addr string) (net.Conn, error) {
+	srvAddr := Scheme + "://" + d.name
+	return func(ctx context.Context, addr string) (net.Conn, error) {
+		if addr != srvAddr {
+			return next(ctx, addr)
+		}
+
+		d.mu.Lock()
+		target := d.target
+		d.mu.Unlock()
+
+		var dialer net.Dialer
+		if target != "" {
+			conn, err := dialer.DialContext(ctx, "tcp", target)
+			if err == nil {
+				return conn, nil
+			}
+			log.FromContext(ctx).Warnf("failed to dial %s, resolving SRV record %s again: %s", target, d.name, err.Error())
+		}
+		newTarget, err := d.Resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if newTarget == target {
+			return nil, errors.Errorf("failed to dial %s resolved from SRV record %s", target, d.name)
+		}
+		conn, err := dialer.DialContext(ctx, "tcp", newTarget)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to dial %s resolved from SRV record %s", newTarget, d.name)
+		}
+		return conn, nil
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package staticroutes provides a chain element adding configured routes to the client side of the connections
+package staticroutes
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type staticRoutesClient struct {
+	routes map[string][]*networkservice.Route
+}
+
+// NewClient - returns a client chain element adding the routes of the network service to the source routes of the
+// connections. Routes with the empty network service key are added to all connections. The routes are added to both
+// the request and the response, so the NSE can't drop them. Should be placed after connectioncontext chain element,
+// so the routes are programmed in VPP on Request and removed on Close.
+func NewClient(routes map[string][]*networkservice.Route) networkservice.NetworkServiceClient {
+	return &staticRoutesClient{
+		routes: routes,
+	}
+}
+
+func (c *staticRoutesClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	routes := append(c.routes[""][:len(c.routes[""]):len(c.routes[""])], c.routes[request.GetConnection().GetNetworkService()]...)
+	if len(routes) == 0 {
+		return next.Client(ctx).Request(ctx, request, opts...)
+	}
+
+	addRoutes(request.GetConnection(), routes)
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+	addRoutes(conn, routes)
+	return conn, nil
+}
+
+func (c *staticRoutesClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+// addRoutes - adds the routes with prefixes missing in the source routes of conn
+func addRoutes(conn *networkservice.Connection, routes []*networkservice.Route) {
+	if conn.GetContext() == nil {
+		conn.Context = &networkservice.ConnectionContext{}
+	}
+	if conn.GetContext().GetIpContext() == nil {
+		conn.GetContext().IpContext = &networkservice.IPContext{}
+	}
+	ipContext := conn.GetContext().GetIpContext()
+
+	prefixes := make(map[string]struct{}, len(ipContext.GetSrcRoutes()))
+	for _, route := range ipContext.GetSrcRoutes() {
+		prefixes[route.GetPrefix()] = struct{}{}
+	}
+	for _, route := range routes {
+		if _, ok := prefixes[route.GetPrefix()]; ok {
+			continue
+		}
+		prefixes[route.GetPrefix()] = struct{}{}
+		ipContext.SrcRoutes = append(ipContext.SrcRoutes, route.Clone())
+	}
+}
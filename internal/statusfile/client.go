@@ -0,0 +1,110 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package statusfile
+
+import (
+	"context"
+	"io"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/tenant"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	interfaces "github.com/networkservicemesh/govpp/binapi/interface"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+type statusFileClient struct {
+	vppConn api.Connection
+	writer  *Writer
+}
+
+// NewClient - returns a client chain element storing the established connections in the writer.
+// Should be placed before the mechanism chain elements, so the interface is already created when Request returns.
+func NewClient(vppConn api.Connection, writer *Writer) networkservice.NetworkServiceClient {
+	return &statusFileClient{
+		vppConn: vppConn,
+		writer:  writer,
+	}
+}
+
+func (c *statusFileClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Connection{
+		NetworkService: conn.GetNetworkService(),
+		ID:             conn.GetId(),
+		Mechanism:      conn.GetMechanism().GetType(),
+		SrcIPs:         conn.GetContext().GetIpContext().GetSrcIpAddrs(),
+		DstIPs:         conn.GetContext().GetIpContext().GetDstIpAddrs(),
+		Tenant:         tenant.FromContext(ctx),
+	}
+	if swIfIndex, ok := ifindex.Load(ctx, true); ok {
+		status.SwIfIndex = uint32(swIfIndex)
+		if status.Interface, err = InterfaceName(ctx, c.vppConn, swIfIndex); err != nil {
+			log.FromContext(ctx).Warnf("failed to get interface name: %s", err.Error())
+		}
+	}
+	if err = c.writer.Store(status); err != nil {
+		log.FromContext(ctx).Errorf("failed to update status file: %s", err.Error())
+	}
+	return conn, nil
+}
+
+func (c *statusFileClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.writer.Delete(conn.GetId()); err != nil {
+		log.FromContext(ctx).Errorf("failed to update status file: %s", err.Error())
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+// InterfaceName - returns the name of the VPP interface with swIfIndex
+func InterfaceName(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) (string, error) {
+	dc, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{
+		SwIfIndex: swIfIndex,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "vppapi SwInterfaceDump returned error")
+	}
+	defer func() { _ = dc.Close() }()
+
+	var name string
+	for {
+		details, recvErr := dc.Recv()
+		if recvErr == io.EOF {
+			return name, nil
+		}
+		if recvErr != nil {
+			return "", errors.Wrap(recvErr, "vppapi SwInterfaceDump returned error")
+		}
+		name = details.InterfaceName
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package statusfile provides a JSON file with the established connections for debugging and for other sidecars
+package statusfile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Connection - status of an established connection
+type Connection struct {
+	NetworkService string   `json:"networkService"`
+	ID             string   `json:"id"`
+	Mechanism      string   `json:"mechanism"`
+	SrcIPs         []string `json:"srcIPs,omitempty"`
+	DstIPs         []string `json:"dstIPs,omitempty"`
+	Interface      string   `json:"interface,omitempty"`
+	SwIfIndex      uint32   `json:"swIfIndex,omitempty"`
+	Tenant         string   `json:"tenant,omitempty"`
+}
+
+// Status - content of the status file
+type Status struct {
+	Connections []*Connection `json:"connections"`
+}
+
+// Writer - keeps the status file up to date with the stored connections
+type Writer struct {
+	path string
+
+	mu          sync.Mutex
+	connections map[string]*Connection
+	removed     bool
+}
+
+// NewWriter - creates a Writer for the file at path
+func NewWriter(path string) *Writer {
+	return &Writer{
+		path:        path,
+		connections: make(map[string]*Connection),
+	}
+}
+
+// Store - adds or updates the connection and rewrites the file
+func (w *Writer) Store(conn *Connection) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.removed {
+		return nil
+	}
+	w.connections[conn.ID] = conn
+	return w.write()
+}
+
+// Delete - deletes the connection and rewrites the file
+func (w *Writer) Delete(id string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.connections[id]; !ok || w.removed {
+		return nil
+	}
+	delete(w.connections, id)
+	return w.write()
+}
+
+// Remove - removes the file, should be called on clean shutdown. The file is not written anymore afterwards, so the
+// connections closed during the shutdown don't bring it back.
+func (w *Writer) Remove() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.connections = make(map[string]*Connection)
+	w.removed = true
+	if err := os.Remove(w.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove status file %s", w.path)
+	}
+	return nil
+}
+
+func (w *Writer) write() error {
+	status := &Status{
+		Connections: make([]*Connection, 0, len(w.connections)),
+	}
+	for _, conn := range w.connections {
+		status.Connections = append(status.Connections, conn)
+	}
+	sort.Slice(status.Connections, func(i, j int) bool {
+		return status.Connections[i].ID < status.Connections[j].ID
+	})
+
+	return WriteJSON(w.path, status)
+}
+
+// WriteJSON - atomically replaces the file at path with v encoded as indented JSON
+func WriteJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %s", filepath.Base(path))
+	}
+	return WriteFile(path, data)
+}
+
+// WriteFile - atomically replaces the file at path with data
+func WriteFile(path string, data []byte) error {
+	// Write to a temporary file and rename it, so readers never see a partially written file
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temporary file for %s", path)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return errors.Wrapf(err, "failed to write %s", tmp.Name())
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close %s", tmp.Name())
+	}
+	if err = os.Chmod(tmp.Name(), 0o644); err != nil {
+		return errors.Wrapf(err, "failed to chmod %s", tmp.Name())
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return errors.Wrapf(err, "failed to rename %s to %s", tmp.Name(), path)
+	}
+	return nil
+}
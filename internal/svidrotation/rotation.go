@@ -0,0 +1,93 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package svidrotation provides following the rotations of the SVID and the trust bundles of an X509 source
+package svidrotation
+
+import (
+	"context"
+	"crypto/x509"
+	"sync/atomic"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Source - X509 source notifying of its updates, e.g. workloadapi.X509Source
+type Source interface {
+	x509svid.Source
+	Updated() <-chan struct{}
+}
+
+// RotateFunc - is called with the new SVID on each rotation
+type RotateFunc func(ctx context.Context, svid *x509svid.SVID)
+
+// Watch - logs the rotations of the SVID and the updates of the trust bundles of source until ctx is done, onRotate
+// is called on each rotation of the SVID. svid is the current SVID of source.
+func Watch(ctx context.Context, source Source, svid *x509svid.SVID, onRotate RotateFunc) {
+	logger := log.FromContext(ctx).WithField("svidrotation", svid.ID.String())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-source.Updated():
+		}
+		rotated, err := source.GetX509SVID()
+		if err != nil {
+			logger.Warnf("failed to get the updated SVID: %s", err.Error())
+			continue
+		}
+		if rotated.Certificates[0].Equal(svid.Certificates[0]) {
+			logger.Debug("trust bundles are updated")
+			continue
+		}
+		if rotated.ID != svid.ID {
+			logger.Warnf("SVID is rotated to another ID %q", rotated.ID)
+		}
+		logger.WithField("serial", rotated.Certificates[0].SerialNumber.String()).
+			WithField("notAfter", rotated.Certificates[0].NotAfter).
+			Info("SVID is rotated")
+		svid = rotated
+		if onRotate != nil {
+			onRotate(ctx, svid)
+		}
+	}
+}
+
+// Authorizer - holds an authorizer which can be replaced while the TLS config using it is in use
+type Authorizer struct {
+	current atomic.Value
+}
+
+// NewAuthorizer - returns an Authorizer holding authorizer
+func NewAuthorizer(authorizer tlsconfig.Authorizer) *Authorizer {
+	a := &Authorizer{}
+	a.Store(authorizer)
+	return a
+}
+
+// Store - replaces the held authorizer, the next handshakes use it
+func (a *Authorizer) Store(authorizer tlsconfig.Authorizer) {
+	a.current.Store(authorizer)
+}
+
+// Authorize - authorizes id with the held authorizer, can be passed as tlsconfig.Authorizer
+func (a *Authorizer) Authorize(id spiffeid.ID, verifiedChains [][]*x509.Certificate) error {
+	return a.current.Load().(tlsconfig.Authorizer)(id, verifiedChains)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package tenant
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type tenantClient struct {
+	tenantOf func(connectionID string) string
+}
+
+// NewClient - returns a client chain element storing the tenant tenantOf returns for the connection in the context of
+// the next chain elements, so the requests from scratch, the refreshes and the heals carry it alike
+func NewClient(tenantOf func(connectionID string) string) networkservice.NetworkServiceClient {
+	return &tenantClient{
+		tenantOf: tenantOf,
+	}
+}
+
+func (c *tenantClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	ctx = WithTenant(ctx, c.tenantOf(request.GetConnection().GetId()))
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (c *tenantClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	ctx = WithTenant(ctx, c.tenantOf(conn.GetId()))
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tokenfile provides a token generator reading the token from a file, e.g. a mounted secret
+package tokenfile
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/fs"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+)
+
+// GeneratorFunc - returns a token generator returning the token from the file at path. The file is watched, so the
+// rotated token is used without a restart. If the token is a JWT with an expiration time, it is used as the token
+// expiration time, otherwise the token expires in lifetime.
+func GeneratorFunc(ctx context.Context, path string, lifetime time.Duration) token.GeneratorFunc {
+	var current atomic.Value
+	current.Store("")
+
+	logger := log.FromContext(ctx).WithField("tokenfile", path)
+	updateToken := func(bytes []byte) {
+		tok := strings.TrimSpace(string(bytes))
+		if tok == "" {
+			logger.Warn("token file is empty or doesn't exist")
+			return
+		}
+		logger.Info("token is updated")
+		current.Store(tok)
+	}
+
+	updateCh := fs.WatchFile(ctx, path)
+	updateToken(<-updateCh)
+	go func() {
+		for update := range updateCh {
+			updateToken(update)
+		}
+	}()
+
+	return func(_ credentials.AuthInfo) (string, time.Time, error) {
+		tok := current.Load().(string)
+		if tok == "" {
+			return "", time.Time{}, errors.Errorf("no token is read from %s", path)
+		}
+		expireTime := time.Now().Add(lifetime)
+		claims := &jwt.RegisteredClaims{}
+		if _, _, err := jwt.NewParser().ParseUnverified(tok, claims); err == nil && claims.ExpiresAt != nil {
+			expireTime = claims.ExpiresAt.Time
+		}
+		return tok, expireTime, nil
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package version provides build information embedded into the binary via -ldflags
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// These values are set at build time:
+//
+//	go build -ldflags "-X github.com/networkservicemesh/cmd-nsc-vpp/internal/version.Version=v1.0.0 ..."
+var (
+	// Version - release version of cmd-nsc-vpp
+	Version = "dev"
+	// Commit - git commit cmd-nsc-vpp is built from
+	Commit = "unknown"
+	// BuildDate - date the binary was built
+	BuildDate = "unknown"
+)
+
+// dependencies - modules which versions are reported along with the build info
+var dependencies = []string{
+	"github.com/networkservicemesh/api",
+	"github.com/networkservicemesh/sdk",
+	"github.com/networkservicemesh/sdk-vpp",
+	"github.com/networkservicemesh/vpphelper",
+}
+
+// Info - returns a human readable build information
+func Info() string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "version: %s, commit: %s, build date: %s, go: %s", Version, Commit, BuildDate, runtime.Version())
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return sb.String()
+	}
+	for _, name := range dependencies {
+		for _, dep := range bi.Deps {
+			if dep.Path == name {
+				_, _ = fmt.Fprintf(&sb, ", %s: %s", dep.Path, dep.Version)
+				break
+			}
+		}
+	}
+	return sb.String()
+}
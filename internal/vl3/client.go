@@ -0,0 +1,224 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package vl3 provides a chain element for the connections to vl3 network services, where the client gets an address
+// of a subnet shared by all clients and the routes to it from the vl3 NSE
+package vl3
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/govpp/binapi/fib_types"
+	interfaces "github.com/networkservicemesh/govpp/binapi/interface"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/govpp/binapi/ip"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/vrf"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+const (
+	// TopologyLabel - label of the connection selecting the topology of the network service
+	TopologyLabel = "topology"
+	// Topology - value of TopologyLabel for the vl3 network services, e.g. kernel://my-vl3-service/nsm-1?topology=vl3
+	Topology = "vl3"
+)
+
+type vl3Client struct {
+	vppConn api.Connection
+}
+
+// NewClient - returns a client chain element for the vl3 connections. It checks that the vl3 NSE has assigned an
+// address to the client and removes the addresses and routes of the previous vl3 prefix from VPP when the NSE
+// changes it, connectioncontext only adds the new ones. Should be placed before connectioncontext.
+func NewClient(vppConn api.Connection) networkservice.NetworkServiceClient {
+	return &vl3Client{
+		vppConn: vppConn,
+	}
+}
+
+func (v *vl3Client) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	if request.GetConnection().GetLabels()[TopologyLabel] != Topology {
+		return next.Client(ctx).Request(ctx, request, opts...)
+	}
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	ipContext := conn.GetContext().GetIpContext()
+	if len(ipContext.GetSrcIpAddrs()) == 0 {
+		err = errors.Errorf("vl3 NSE has not assigned an address to connection %s", conn.GetId())
+	} else {
+		err = v.removeStale(ctx, ipContext)
+	}
+	if err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := v.Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+		return nil, err
+	}
+
+	log.FromContext(ctx).
+		WithField("addrs", ipContext.GetSrcIpAddrs()).
+		WithField("routes", ipContext.GetSrcRoutesWithExplicitNextHop()).
+		Info("vl3 connection is configured")
+	store(ctx, &state{
+		addrs:  ipContext.GetSrcIpAddrs(),
+		routes: ipContext.GetSrcRoutesWithExplicitNextHop(),
+	})
+	return conn, nil
+}
+
+func (v *vl3Client) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	del(ctx)
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+// removeStale - removes the addresses and the routes programmed by the previous request and missing in ipContext
+func (v *vl3Client) removeStale(ctx context.Context, ipContext *networkservice.IPContext) error {
+	prev, ok := load(ctx)
+	if !ok {
+		return nil
+	}
+	swIfIndex, ok := ifindex.Load(ctx, true)
+	if !ok {
+		return nil
+	}
+
+	for _, addr := range prev.addrs {
+		if contains(ipContext.GetSrcIpAddrs(), addr) {
+			continue
+		}
+		if err := v.delAddress(ctx, swIfIndex, addr); err != nil {
+			return err
+		}
+	}
+	for _, route := range prev.routes {
+		if containsRoute(ipContext.GetSrcRoutesWithExplicitNextHop(), route) {
+			continue
+		}
+		if err := v.delRoute(ctx, swIfIndex, route); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *vl3Client) delAddress(ctx context.Context, swIfIndex interface_types.InterfaceIndex, addr string) error {
+	addrIP, ipNet, err := net.ParseCIDR(addr)
+	if err != nil {
+		return errors.Wrapf(err, "invalid address %s", addr)
+	}
+	ipNet.IP = addrIP
+
+	now := time.Now()
+	if _, err = interfaces.NewServiceClient(v.vppConn).SwInterfaceAddDelAddress(ctx, &interfaces.SwInterfaceAddDelAddress{
+		SwIfIndex: swIfIndex,
+		IsAdd:     false,
+		Prefix:    types.ToVppAddressWithPrefix(ipNet),
+	}); err != nil {
+		return errors.Wrap(err, "vppapi SwInterfaceAddDelAddress returned error")
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("prefix", addr).
+		WithField("isAdd", false).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceAddDelAddress").Debug("completed")
+	return nil
+}
+
+func (v *vl3Client) delRoute(ctx context.Context, swIfIndex interface_types.InterfaceIndex, route *networkservice.Route) error {
+	prefix := route.GetPrefixIPNet()
+	if prefix == nil {
+		return errors.Errorf("invalid route prefix %s", route.GetPrefix())
+	}
+	isIPv6 := prefix.IP.To4() == nil
+	tableID, _ := vrf.Load(ctx, true, isIPv6)
+	vppRoute := ip.IPRoute{
+		TableID: tableID,
+		Prefix:  types.ToVppPrefix(prefix),
+		NPaths:  1,
+		Paths: []fib_types.FibPath{
+			{
+				SwIfIndex: uint32(swIfIndex),
+				Weight:    1,
+				Type:      fib_types.FIB_API_PATH_TYPE_NORMAL,
+				Flags:     fib_types.FIB_API_PATH_FLAG_NONE,
+				Proto:     types.IsV6toFibProto(isIPv6),
+			},
+		},
+	}
+	if nh := route.GetNextHopIP(); nh != nil {
+		vppRoute.Paths[0].Nh.Address = types.ToVppAddress(nh).Un
+	}
+
+	now := time.Now()
+	if _, err := ip.NewServiceClient(v.vppConn).IPRouteAddDel(ctx, &ip.IPRouteAddDel{
+		IsAdd: false,
+		Route: vppRoute,
+	}); err != nil {
+		return errors.Wrap(err, "vppapi IPRouteAddDel returned error")
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("prefix", route.GetPrefix()).
+		WithField("tableID", tableID).
+		WithField("isAdd", false).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IPRouteAddDel").Debug("completed")
+	return nil
+}
+
+func contains(addrs []string, addr string) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func containsRoute(routes []*networkservice.Route, route *networkservice.Route) bool {
+	for _, r := range routes {
+		if r.GetPrefix() == route.GetPrefix() && r.GetNextHop() == route.GetNextHop() {
+			return true
+		}
+	}
+	return false
+}
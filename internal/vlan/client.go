@@ -0,0 +1,98 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package vlan provides a client chain element attaching the client to the VLAN connections through VLAN
+// subinterfaces of the VPP interfaces
+package vlan
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	vlanmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vlan"
+	interfaces "github.com/networkservicemesh/govpp/binapi/interface"
+	"github.com/networkservicemesh/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	sdkvlan "github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vlan"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+// ViaLabel - label of the connection selecting the parent interface of the VLAN subinterface in devices
+const ViaLabel = "via"
+
+type vlanClient struct {
+	vppConn api.Connection
+}
+
+// NewClient - returns a client chain element creating a VLAN subinterface with the VLAN ID of the VLAN connection on
+// the VPP interface devices maps the via label of the connection to, the interface itself is used if the VLAN ID is 0.
+// The subinterface is deleted on Close.
+func NewClient(vppConn api.Connection, devices map[string]string) networkservice.NetworkServiceClient {
+	return chain.NewNetworkServiceClient(
+		&vlanClient{
+			vppConn: vppConn,
+		},
+		sdkvlan.NewClient(vppConn, devices),
+	)
+}
+
+func (c *vlanClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (c *vlanClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if vlanmech.ToMechanism(conn.GetMechanism()) == nil {
+		return next.Client(ctx).Close(ctx, conn, opts...)
+	}
+	// The VLAN client forgets the subinterface on Close, but leaves it in VPP
+	swIfIndex, ok := ifindex.Load(ctx, true)
+	vlanID, _ := sdkvlan.Load(ctx, true)
+
+	rv, err := next.Client(ctx).Close(ctx, conn, opts...)
+
+	if ok && vlanID != 0 {
+		if delErr := deleteSubif(ctx, c.vppConn, swIfIndex); delErr != nil {
+			log.FromContext(ctx).Errorf("failed to delete VLAN subinterface: %s", delErr.Error())
+		}
+	}
+	return rv, err
+}
+
+func deleteSubif(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) error {
+	now := time.Now()
+	if _, err := interfaces.NewServiceClient(vppConn).DeleteSubif(ctx, &interfaces.DeleteSubif{
+		SwIfIndex: swIfIndex,
+	}); err != nil {
+		return errors.Wrap(err, "vppapi DeleteSubif returned error")
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "DeleteSubif").Debug("completed")
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vppinit provides helpers to prepare VPP before the client starts using it
+package vppinit
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+	"go.fd.io/govpp/binapi/vlib"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// RunCommands - runs each of cmds on VPP in order, as if they were passed to vppctl
+func RunCommands(ctx context.Context, vppConn api.Connection, cmds ...string) error {
+	for _, cmd := range cmds {
+		if _, err := Output(ctx, vppConn, cmd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Output - runs cmd on VPP as if it was passed to vppctl, returns its output
+func Output(ctx context.Context, vppConn api.Connection, cmd string) (string, error) {
+	now := time.Now()
+	reply, err := vlib.NewServiceClient(vppConn).CliInband(ctx, &vlib.CliInband{
+		Cmd: cmd,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "vppapi CliInband returned error for command %q", cmd)
+	}
+	log.FromContext(ctx).
+		WithField("cmd", cmd).
+		WithField("reply", reply.Reply).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "CliInband").Debug("completed")
+	return reply.Reply, nil
+}
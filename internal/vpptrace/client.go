@@ -0,0 +1,56 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vpptrace
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type vppTraceClient struct {
+	trace *Trace
+}
+
+// NewClient - returns a client chain element dumping the VPP API calls made while a Request was in progress if it
+// fails. Should be placed before the VPP chain elements, which should use the connections of the trace.
+func NewClient(trace *Trace) networkservice.NetworkServiceClient {
+	return &vppTraceClient{
+		trace: trace,
+	}
+}
+
+func (c *vppTraceClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	seq := c.trace.last()
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		c.trace.dump(ctx, request.GetConnection().GetId(), seq, err)
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (c *vppTraceClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
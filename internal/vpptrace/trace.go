@@ -0,0 +1,193 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package vpptrace provides a VPP connection recording the recent VPP API calls and a chain element dumping them when
+// a request fails
+package vpptrace
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/statusfile"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// call - recorded VPP API call or stream message
+type call struct {
+	seq      uint64
+	time     time.Time
+	req      api.Message
+	reply    api.Message
+	err      error
+	duration time.Duration
+}
+
+func (c *call) String() string {
+	var b strings.Builder
+	b.WriteString(c.time.Format("15:04:05.000000"))
+	if c.req != nil {
+		_, _ = fmt.Fprintf(&b, " %s %+v", c.req.GetMessageName(), c.req)
+	}
+	if c.reply != nil {
+		_, _ = fmt.Fprintf(&b, " -> %s %+v", c.reply.GetMessageName(), c.reply)
+	}
+	if c.err != nil {
+		_, _ = fmt.Fprintf(&b, " -> error: %s", c.err.Error())
+	}
+	if c.duration > 0 {
+		_, _ = fmt.Fprintf(&b, " (%v)", c.duration)
+	}
+	return b.String()
+}
+
+// Trace - ring buffer of the recent VPP API calls made through its connections. Shared by the chains of all NSMgrs
+// and VPP instances.
+type Trace struct {
+	dir string
+
+	mu    sync.Mutex
+	calls []call
+	seq   uint64
+}
+
+// NewTrace - creates a Trace keeping the last size VPP API calls, the calls are dumped to the files in dir or logged if
+// dir is empty
+func NewTrace(size int, dir string) *Trace {
+	return &Trace{
+		dir:   dir,
+		calls: make([]call, size),
+	}
+}
+
+// Connection - returns vppConn recording its VPP API calls to the trace
+func (t *Trace) Connection(vppConn api.Connection) api.Connection {
+	return &traceConnection{
+		Connection: vppConn,
+		trace:      t,
+	}
+}
+
+func (t *Trace) record(c *call) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.seq++
+	c.seq = t.seq
+	t.calls[t.seq%uint64(len(t.calls))] = *c
+}
+
+// last - returns the sequence number of the last recorded call
+func (t *Trace) last() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.seq
+}
+
+// since - returns the calls recorded after the call with the seq which are still in the buffer, oldest first
+func (t *Trace) since(seq uint64) []call {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var calls []call
+	for s := seq + 1; s <= t.seq; s++ {
+		if c := t.calls[s%uint64(len(t.calls))]; c.seq == s {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// dump - logs the calls recorded after seq or writes them to a file of the directory. The calls of the concurrent
+// requests are included as well.
+func (t *Trace) dump(ctx context.Context, connID string, seq uint64, reqErr error) {
+	logger := log.FromContext(ctx).WithField("vpptrace", "Trace")
+	calls := t.since(seq)
+	if len(calls) == 0 {
+		return
+	}
+	var b strings.Builder
+	for i := range calls {
+		b.WriteString(calls[i].String())
+		b.WriteByte('\n')
+	}
+	if t.dir == "" {
+		logger.Errorf("request of connection %s failed: %s, VPP API calls:\n%s", connID, reqErr.Error(), b.String())
+		return
+	}
+	path := filepath.Join(t.dir, fmt.Sprintf("%s-%s.trace", connID, time.Now().UTC().Format("20060102T150405.000")))
+	if err := statusfile.WriteFile(path, []byte(b.String())); err != nil {
+		logger.Errorf("failed to write VPP API calls of connection %s: %s", connID, err.Error())
+		return
+	}
+	logger.Errorf("request of connection %s failed: %s, VPP API calls are written to %s", connID, reqErr.Error(), path)
+}
+
+type traceConnection struct {
+	api.Connection
+	trace *Trace
+}
+
+func (c *traceConnection) Invoke(ctx context.Context, req, reply api.Message) error {
+	now := time.Now()
+	err := c.Connection.Invoke(ctx, req, reply)
+	recorded := &call{time: now, req: req, err: err, duration: time.Since(now)}
+	if err == nil {
+		recorded.reply = reply
+	}
+	c.trace.record(recorded)
+	return err
+}
+
+func (c *traceConnection) NewStream(ctx context.Context, options ...api.StreamOption) (api.Stream, error) {
+	stream, err := c.Connection.NewStream(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &traceStream{
+		Stream: stream,
+		trace:  c.trace,
+	}, nil
+}
+
+// traceStream - records each message sent and received separately, the dumps are streamed
+type traceStream struct {
+	api.Stream
+	trace *Trace
+}
+
+func (s *traceStream) SendMsg(msg api.Message) error {
+	err := s.Stream.SendMsg(msg)
+	s.trace.record(&call{time: time.Now(), req: msg, err: err})
+	return err
+}
+
+func (s *traceStream) RecvMsg() (api.Message, error) {
+	msg, err := s.Stream.RecvMsg()
+	s.trace.record(&call{time: time.Now(), reply: msg, err: err})
+	return msg, err
+}
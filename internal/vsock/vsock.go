@@ -0,0 +1,98 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package vsock provides dialing NSMgr over vsock for VM isolated setups
+package vsock
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mdlayher/vsock"
+	"github.com/pkg/errors"
+)
+
+// Scheme - URL scheme of the vsock addresses: vsock://CID:PORT
+const Scheme = "vsock"
+
+// ContextDialer - dials vsock for vsock://CID:PORT addresses, unix for the socket paths and tcp for the rest. Can be
+// used with grpc.WithContextDialer, gRPC passes the vsock targets to it as they are.
+func ContextDialer(ctx context.Context, addr string) (net.Conn, error) {
+	if !strings.HasPrefix(addr, Scheme+"://") {
+		var d net.Dialer
+		if strings.HasPrefix(addr, "/") || strings.HasPrefix(addr, "@") {
+			return d.DialContext(ctx, "unix", addr)
+		}
+		return d.DialContext(ctx, "tcp", addr)
+	}
+
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse vsock address %s", addr)
+	}
+	contextID, port, err := Parse(u)
+	if err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		conn net.Conn
+		err  error
+	}
+	resultCh := make(chan result, 1)
+	go func() {
+		conn, dialErr := vsock.Dial(contextID, port, nil)
+		resultCh <- result{conn: conn, err: dialErr}
+	}()
+
+	select {
+	case <-ctx.Done():
+		// vsock.Dial doesn't take a context, so the connection is closed as soon as it is dialed
+		go func() {
+			if r := <-resultCh; r.err == nil {
+				_ = r.conn.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	case r := <-resultCh:
+		if r.err != nil {
+			return nil, errors.Wrapf(r.err, "failed to dial %s", addr)
+		}
+		return r.conn, nil
+	}
+}
+
+// Parse - returns the context ID and the port of the vsock://CID:PORT URL
+func Parse(u *url.URL) (contextID, port uint32, err error) {
+	if u.Scheme != Scheme {
+		return 0, 0, errors.Errorf("%s is not a vsock URL", u.String())
+	}
+	cid, err := strconv.ParseUint(u.Hostname(), 10, 32)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid vsock context ID in %s", u.String())
+	}
+	p, err := strconv.ParseUint(u.Port(), 10, 32)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid vsock port in %s", u.String())
+	}
+	return uint32(cid), uint32(p), nil
+}
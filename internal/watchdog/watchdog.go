@@ -0,0 +1,126 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package watchdog provides a watchdog of the client connections reporting the connections which stay down, e.g.
+// after the heal has given up
+package watchdog
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/connwatch"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// Watchdog - reports the ID of each client connection which is down or deleted by NSMgr for longer than the
+// threshold, the report is repeated each threshold until the connection is up again or Forget is called
+type Watchdog struct {
+	threshold  time.Duration
+	recoveries chan string
+
+	mu     sync.Mutex
+	timers map[string]*time.Timer
+}
+
+// NewWatchdog - creates a Watchdog reporting the connections which are down for longer than threshold
+func NewWatchdog(threshold time.Duration) *Watchdog {
+	return &Watchdog{
+		threshold:  threshold,
+		recoveries: make(chan string),
+		timers:     make(map[string]*time.Timer),
+	}
+}
+
+// Recoveries - returns the channel the IDs of the connections to recover are sent to
+func (w *Watchdog) Recoveries() <-chan string {
+	return w.recoveries
+}
+
+// Down - returns true if the connection with the id is still down, the connection may come up while its ID is waiting
+// to be received from Recoveries
+func (w *Watchdog) Down(id string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, ok := w.timers[id]
+	return ok
+}
+
+// Forget - stops reporting the connection with the id until it is down again, should be called for the
+// connections closed by the client
+func (w *Watchdog) Forget(id string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if timer, ok := w.timers[id]; ok {
+		timer.Stop()
+		delete(w.timers, id)
+	}
+}
+
+// Watch - follows the connections with the client connection ID starting with idPrefix through the monitor stream
+// until ctx is done. The stream is opened again if it fails.
+func (w *Watchdog) Watch(ctx context.Context, monitorClient networkservice.MonitorConnectionClient, idPrefix string) {
+	defer w.forgetAll()
+	connwatch.Watch(ctx, monitorClient, idPrefix, "watchdog", w.observe)
+}
+
+func (w *Watchdog) observe(ctx context.Context, eventType networkservice.ConnectionEventType, id string, conn *networkservice.Connection) {
+	if eventType != networkservice.ConnectionEventType_DELETE && conn.GetState() == networkservice.State_UP {
+		w.Forget(id)
+		return
+	}
+	if eventType == networkservice.ConnectionEventType_DELETE || conn.GetState() == networkservice.State_DOWN {
+		w.arm(ctx, id)
+	}
+}
+
+// arm - starts the timer of the connection with the id unless it is already running
+func (w *Watchdog) arm(ctx context.Context, id string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.timers[id]; ok {
+		return
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(w.threshold, func() {
+		select {
+		case <-ctx.Done():
+			return
+		case w.recoveries <- id:
+		}
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if w.timers[id] == timer {
+			timer.Reset(w.threshold)
+		}
+	})
+	w.timers[id] = timer
+}
+
+func (w *Watchdog) forgetAll() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for id, timer := range w.timers {
+		timer.Stop()
+		delete(w.timers, id)
+	}
+}
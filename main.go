@@ -2,7 +2,7 @@
 //
 // Copyright (c) 2023-2024 Cisco and/or its affiliates.
 //
-// Copyright (c) 2024 OpenInfra Foundation Europe. All rights reserved.
+// Copyright (c) 2024-2026 OpenInfra Foundation Europe. All rights reserved.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -25,11 +25,15 @@ package main
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -37,28 +41,89 @@ import (
 	"github.com/edwarnicke/debug"
 	"github.com/edwarnicke/grpcfd"
 	"github.com/kelseyhightower/envconfig"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	"github.com/spiffe/go-spiffe/v2/workloadapi"
+	"go.fd.io/govpp/api"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	insecurecreds "google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 
 	"github.com/networkservicemesh/vpphelper"
 
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/admin"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/allconnected"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/clientinfo"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/closecheck"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/connmetrics"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/connwatch"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/eventlog"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/excludedprefixesfile"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/failover"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/healthlog"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/hostlink"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/idle"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/idsuffix"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/ifstats"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/inflight"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/insecure"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/interfacesfile"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/ipfamily"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/kernelname"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/lasterrors"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/linkup"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/localmonitor"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/macaddr"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/memifrole"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/memifsize"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/memifsocket"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/metrics"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/metricsfile"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/none"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/pathlog"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/pcap"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/phases"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/pingprobe"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/policer"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/preferredip"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/prefixcollision"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/retry"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/spans"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/srv"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/staticroutes"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/statusfile"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/svidrotation"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/tenant"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/tokenfile"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/version"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/vl3"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/vlan"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/vppinit"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/vpptrace"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/vsock"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/watchdog"
+
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	memifmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
+	vlanmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vlan"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/connectioncontext"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/memif"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/up"
 	vppheal "github.com/networkservicemesh/sdk-vpp/pkg/tools/heal"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/client"
-	"github.com/networkservicemesh/sdk/pkg/networkservice/common/clientinfo"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/excludedprefixes"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/heal"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/sendfd"
-	"github.com/networkservicemesh/sdk/pkg/networkservice/common/retry"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/null"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/upstreamrefresh"
-	"github.com/networkservicemesh/sdk/pkg/tools/awarenessgroups"
-	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/log/logruslogger"
 	"github.com/networkservicemesh/sdk/pkg/tools/nsurl"
@@ -69,28 +134,25 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/tracing"
 )
 
-// Config - configuration for cmd-forwarder-vpp
-type Config struct {
-	Name                  string                  `default:"cmd-nsc-vpp" desc:"Name of Endpoint"`
-	DialTimeout           time.Duration           `default:"5s" desc:"timeout to dial NSMgr" split_words:"true"`
-	RequestTimeout        time.Duration           `default:"15s" desc:"timeout to request NSE" split_words:"true"`
-	ConnectTo             url.URL                 `default:"unix:///var/lib/networkservicemesh/nsm.io.sock" desc:"url to connect to" split_words:"true"`
-	MaxTokenLifetime      time.Duration           `default:"10m" desc:"maximum lifetime of tokens" split_words:"true"`
-	NetworkServices       []url.URL               `default:"" desc:"A list of Network Service Requests" split_words:"true"`
-	AwarenessGroups       awarenessgroups.Decoder `defailt:"" desc:"Awareness groups for mutually aware NSEs" split_words:"true"`
-	LogLevel              string                  `default:"INFO" desc:"Log level" split_words:"true"`
-	OpenTelemetryEndpoint string                  `default:"otel-collector.observability.svc.cluster.local:4317" desc:"OpenTelemetry Collector Endpoint" split_words:"true"`
-	MetricsExportInterval time.Duration           `default:"10s" desc:"interval between mertics exports" split_words:"true"`
-
-	LivenessCheckEnabled  bool          `default:"true" desc:"Dataplane liveness check enabled/disabled" split_words:"true"`
-	LivenessCheckInterval time.Duration `default:"1200ms" desc:"Dataplane liveness check interval" split_words:"true"`
-	LivenessCheckTimeout  time.Duration `default:"1s" desc:"Dataplane liveness check timeout" split_words:"true"`
-
-	PprofEnabled  bool   `default:"false" desc:"is pprof enabled" split_words:"true"`
-	PprofListenOn string `default:"localhost:6060" desc:"pprof URL to ListenAndServe" split_words:"true"`
+func main() {
+	// ********************************************************************************
+	// Print build info and exit if requested
+	// ********************************************************************************
+	if len(os.Args) > 1 && (os.Args[1] == "version" || os.Args[1] == "--version") {
+		fmt.Println(version.Info())
+		return
+	}
+
+	// The deferred cleanup of run is done before the exit
+	if err := run(); err != nil {
+		logrus.Errorf("exiting with code %d: %s", exitCode(err), err.Error())
+		os.Exit(exitCode(err))
+	}
 }
 
-func main() {
+// run - runs the client until it is stopped, returns the error classified with the exit code of the process if it
+// fails
+func run() (runErr error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -125,41 +187,149 @@ func main() {
 	// ********************************************************************************
 	now := time.Now()
 
+	log.FromContext(ctx).Infof("Build info: %s", version.Info())
+
 	config := &Config{}
 	if err := envconfig.Usage("nsm", config); err != nil {
-		logrus.Fatal(err)
+		return withExitCode(exitConfig, err)
+	}
+	if path := os.Getenv(configFileEnv); path != "" {
+		if err := applyConfigFile(path); err != nil {
+			return withExitCode(exitConfig, err)
+		}
+		log.FromContext(ctx).Infof("config file %s is applied, the environment variables take precedence", path)
 	}
 	if err := envconfig.Process("nsm", config); err != nil {
-		logrus.Fatalf("error processing config from env: %+v", err)
+		return withExitCode(exitConfig, errors.Wrap(err, "error processing config from env"))
+	}
+	if err := config.expandName(); err != nil {
+		return withExitCode(exitConfig, errors.Wrap(err, "error expanding name"))
+	}
+	if config.ConnectToSRV != "" {
+		if _, ok := os.LookupEnv(connectToEnv); ok {
+			log.FromContext(ctx).Warnf("%s is set, SRV record %s is not used", connectToEnv, config.ConnectToSRV)
+		} else {
+			config.ConnectTo = url.URL{Scheme: srv.Scheme, Host: config.ConnectToSRV}
+		}
+	}
+	if err := config.normalizeConnectTo(); err != nil {
+		return withExitCode(exitConfig, err)
+	}
+	if config.NetworkServicesFile != "" {
+		services, err := readNetworkServices(config.NetworkServicesFile)
+		if err != nil {
+			return withExitCode(exitConfig, errors.Wrap(err, "error reading network services"))
+		}
+		config.NetworkServices = services
+	}
+	if err := config.loadAwarenessGroupsFiles(); err != nil {
+		return withExitCode(exitConfig, err)
+	}
+	if err := config.expandMechanismParameters(); err != nil {
+		return withExitCode(exitConfig, errors.Wrap(err, "error expanding mechanism parameters"))
 	}
-	log.FromContext(ctx).Infof("Config: %#v", config)
+	if err := config.validate(); err != nil {
+		return withExitCode(exitConfig, errors.Wrap(err, "error validating config"))
+	}
+	log.FromContext(ctx).Infof("Config: %#v", config.redacted())
+	logMemifSizes(ctx, config)
 
 	l, err := logrus.ParseLevel(config.LogLevel)
 	if err != nil {
-		logrus.Fatalf("invalid log level %s", config.LogLevel)
+		return withExitCode(exitConfig, errors.Errorf("invalid log level %s", config.LogLevel))
 	}
 	logrus.SetLevel(l)
+	switch config.LogFormat {
+	case "text":
+	case "json":
+		// logruslogger.New sets its own text formatter, so this one has to be set after it
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	default:
+		return withExitCode(exitConfig, errors.Errorf("invalid log format %s", config.LogFormat))
+	}
+	signalLevel, err := logrus.ParseLevel(config.SignalLogLevel)
+	if err != nil {
+		return withExitCode(exitConfig, errors.Errorf("invalid signal log level %s", config.SignalLogLevel))
+	}
 	logruslogger.SetupLevelChangeOnSignal(ctx, map[os.Signal]logrus.Level{
-		syscall.SIGUSR1: logrus.TraceLevel,
+		syscall.SIGUSR1: signalLevel,
 		syscall.SIGUSR2: l,
 	})
+	lastErrors := lasterrors.NewHook(lastErrorsSize)
+	if config.StateDump {
+		logrus.AddHook(lastErrors)
+	}
+
+	vppConfig := vpphelper.DefaultVPPConfTemplate
+	if config.VppConfigPath != "" {
+		vppConfigFile, readErr := os.ReadFile(config.VppConfigPath)
+		if readErr != nil {
+			return withExitCode(exitConfig, errors.Wrapf(readErr, "error reading VPP config %s", config.VppConfigPath))
+		}
+		vppConfig = string(vppConfigFile)
+	}
+	// Validated above
+	vppCPUs, _ := config.vppCPUs()
+	vppConfig = pinVppThreads(vppConfig, vppCPUs, config.VppWorkers)
+
+	idSuffix := config.ConnectionIDSuffix
+	if idSuffix == "" && config.ConnectionIDSuffixFile != "" {
+		if idSuffix, err = idsuffix.Load(config.ConnectionIDSuffixFile); err != nil {
+			return withExitCode(exitConfig, errors.Wrap(err, "error loading connection ID suffix"))
+		}
+	}
 
 	log.FromContext(ctx).WithField("duration", time.Since(now)).Infof("completed phase 1: get config from environment")
 
+	// ********************************************************************************
+	// Print what would be requested and exit in dry run mode
+	// ********************************************************************************
+	if config.DryRun {
+		if err = dryRun(config, idSuffix); err != nil {
+			return withExitCode(exitConfig, errors.Wrap(err, "dry run has failed"))
+		}
+		return nil
+	}
+
 	// ********************************************************************************
 	// Configure Open Telemetry
 	// ********************************************************************************
+	// The metrics have their own meter provider, so they are collected for the metrics file without OpenTelemetry
+	var metricReaders []sdkmetric.Reader
 	if opentelemetry.IsEnabled() {
 		collectorAddress := config.OpenTelemetryEndpoint
 		spanExporter := opentelemetry.InitSpanExporter(ctx, collectorAddress)
-		metricExporter := opentelemetry.InitOPTLMetricExporter(ctx, collectorAddress, config.MetricsExportInterval)
-		o := opentelemetry.Init(ctx, spanExporter, metricExporter, config.Name)
+		if metricExporter := opentelemetry.InitOPTLMetricExporter(ctx, collectorAddress, config.MetricsExportInterval); metricExporter != nil {
+			metricReaders = append(metricReaders, metricExporter)
+		}
+		o := opentelemetry.Init(ctx, spanExporter, nil, config.Name)
 		defer func() {
 			if err = o.Close(); err != nil {
 				log.FromContext(ctx).Error(err.Error())
 			}
 		}()
 	}
+	if config.MetricsFile != "" {
+		metricsWriter, writerErr := metricsfile.NewWriter(config.MetricsFile)
+		if writerErr != nil {
+			return withExitCode(exitConfig, writerErr)
+		}
+		metricReaders = append(metricReaders, metricsWriter.Reader())
+		go metricsWriter.Run(ctx, config.MetricsExportInterval)
+		defer func() {
+			if err = metricsWriter.Truncate(); err != nil {
+				log.FromContext(ctx).Error(err.Error())
+			}
+		}()
+	}
+	if len(metricReaders) > 0 {
+		meterProvider := metrics.Init(ctx, config.Name, metricReaders...)
+		defer func() {
+			if err = meterProvider.Shutdown(ctx); err != nil {
+				log.FromContext(ctx).Errorf("failed to shutdown meter provider: %s", err.Error())
+			}
+		}()
+	}
 
 	// ********************************************************************************
 	// Configure pprof
@@ -168,193 +338,1080 @@ func main() {
 		go pprofutils.ListenAndServe(ctx, config.PprofListenOn)
 	}
 
+	// ********************************************************************************
+	// Configure admin endpoints
+	// ********************************************************************************
+	adminServer := admin.NewServer()
+	adminServer.Handle("/loglevel", admin.LogLevelHandler())
+	drains := newDrainer()
+	if config.AdminToken != "" {
+		adminServer.Handle("/drain", admin.RequireToken(config.AdminToken, admin.DrainHandler(drains)))
+		adminServer.Handle("/resume", admin.RequireToken(config.AdminToken, admin.ResumeHandler(drains)))
+	}
+	// Updated when the network services are reloaded
+	var effectiveConfig atomic.Value
+	effectiveConfig.Store(config.effective())
+	if config.AdminToken != "" {
+		adminServer.Handle("/config", admin.RequireToken(config.AdminToken, admin.ConfigHandler(effectiveConfig.Load)))
+	} else {
+		adminServer.Handle("/config", admin.ConfigHandler(effectiveConfig.Load))
+	}
+	if config.AdminListenOn != "" {
+		go adminServer.ListenAndServe(ctx, config.AdminListenOn)
+	}
+
 	// ********************************************************************************
 	log.FromContext(ctx).Infof("executing phase 2: run vpp and get a connection to it (time since start: %s)", time.Since(starttime))
 	// ********************************************************************************
+	// The expired startup cancels ctx, so the client stops as on a shutdown and exits with the expiry
+	startup := newStartupWatchdog(ctx, cancel, config.StartupTimeout)
+	defer func() {
+		if startupErr := startup.err(); startupErr != nil {
+			runErr = startupErr
+		}
+	}()
+	startup.setPhase("phase 2: run vpp and get a connection to it")
 	now = time.Now()
 
-	vppConn, vppErrCh := vpphelper.StartAndDialContext(ctx)
-	exitOnErrCh(ctx, cancel, vppErrCh)
+	if config.Mode != modeRun {
+		if err = checkVppConfigFile(); err != nil {
+			return withExitCode(exitConfig, err)
+		}
+		if vppConfig, err = useHugepages(ctx, config, vppConfig); err != nil {
+			return withExitCode(exitVpp, err)
+		}
+	}
+	vppOptions := []vpphelper.Option{vpphelper.WithVppConfig(vppConfig)}
+	restarter := newVppRestarter(config, vppConfig)
 
+	var vpp *vppProcess
+	if config.Mode == modeRun {
+		log.FromContext(ctx).Infof("run mode: connecting to VPP at %s", config.VppAPISocket)
+		vpp = dialVpp(ctx, config.VppAPISocket)
+	} else if vpp, err = startVpp(ctx, config, vppOptions...); err != nil {
+		return withExitCode(exitVpp, err)
+	}
+	if config.Mode == modeInit {
+		if err = vppinit.RunCommands(ctx, vpp.conn, "show version"); err != nil {
+			return withExitCode(exitVpp, errors.Wrap(err, "VPP is not ready"))
+		}
+		log.FromContext(ctx).WithField("duration", time.Since(now)).Info("init mode: VPP is ready, exiting")
+		// The deferred cleanup is done as usual, VPP is left running for the client in run mode
+		vpp.keep()
+		return nil
+	}
 	defer func() {
-		cancel()
-		<-vppErrCh
+		vpp.stop()
 	}()
 
+	// VPP dying before all connections are established leaves nothing to close toward NSMgr, so the client just stops
+	go func(died <-chan struct{}) {
+		select {
+		case <-died:
+			cancel()
+		case <-startup.finished():
+		}
+	}(vpp.died)
+
 	log.FromContext(ctx).WithField("duration", time.Since(now)).Info("completed phase 2: run vpp and get a connection to it")
 
 	// ********************************************************************************
 	log.FromContext(ctx).Infof("executing phase 3: retrieving svid, check spire agent logs if this is the last line you see (time since start: %s)", time.Since(starttime))
 	// ********************************************************************************
+	startup.setPhase("phase 3: retrieving svid")
 	now = time.Now()
 
-	source, err := workloadapi.NewX509Source(ctx)
-	if err != nil {
-		logrus.Fatalf("error getting x509 source: %+v", err)
+	var transportCredentials credentials.TransportCredentials
+	var tokenGenerator token.GeneratorFunc
+	// Stays nil in insecure mode, there is no SVID
+	var trustDomainChanges <-chan spiffeid.TrustDomain
+	if config.InsecureMode {
+		log.FromContext(ctx).Warn("NSC is running in insecure mode: SVID is not retrieved, connections are neither encrypted nor authenticated")
+		transportCredentials = insecurecreds.NewCredentials()
+		tokenGenerator = insecure.TokenGeneratorFunc(config.Name, config.MaxTokenLifetime)
+	} else {
+		if transportCredentials, tokenGenerator, trustDomainChanges, err = spiffeCredentials(ctx, config); err != nil {
+			return withExitCode(exitAuth, err)
+		}
 	}
-	svid, err := source.GetX509SVID()
-	if err != nil {
-		logrus.Fatalf("error getting x509 svid: %+v", err)
+	if config.TokenFile != "" {
+		tokenGenerator = tokenfile.GeneratorFunc(ctx, config.TokenFile, config.MaxTokenLifetime)
 	}
-	logrus.Infof("SVID: %q", svid.ID)
 
 	log.FromContext(ctx).WithField("duration", time.Since(now)).Info("completed phase 3: retrieving svid")
 
-	tlsClientConfig := tlsconfig.MTLSClientConfig(source, source, tlsconfig.AuthorizeAny())
-	tlsClientConfig.MinVersion = tls.VersionTLS12
-
 	// ********************************************************************************
 	log.FromContext(ctx).Infof("executing phase 4: create network service client (time since start: %s)", time.Since(starttime))
 	// ********************************************************************************
+	startup.setPhase("phase 4: create network service client")
 	dialOptions := append(tracing.WithTracingDial(),
 		grpc.WithDefaultCallOptions(
 			grpc.WaitForReady(true),
-			grpc.PerRPCCredentials(token.NewPerRPCCredentials(spiffejwt.TokenGeneratorFunc(source, config.MaxTokenLifetime)))),
+			grpc.PerRPCCredentials(token.NewPerRPCCredentials(tokenGenerator))),
 		grpc.WithTransportCredentials(
-			grpcfd.TransportCredentials(credentials.NewTLS(tlsClientConfig))),
+			grpcfd.TransportCredentials(transportCredentials)),
 		grpcfd.WithChainStreamInterceptor(),
 		grpcfd.WithChainUnaryInterceptor(),
 	)
+	if config.InsecureMode {
+		dialOptions = append(dialOptions, insecure.DialOptions()...)
+	}
+	var contextDialer func(context.Context, string) (net.Conn, error)
+	for _, u := range append([]url.URL{config.ConnectTo}, config.ConnectToFallbacks...) {
+		if u.Scheme == vsock.Scheme {
+			contextDialer = vsock.ContextDialer
+			break
+		}
+	}
+	if config.ConnectTo.Scheme == srv.Scheme {
+		srvDialer := srv.NewDialer(config.ConnectTo.Host)
+		if _, err = srvDialer.Resolve(ctx); err != nil {
+			if !config.WaitForNSMgr {
+				return withExitCode(exitDial, err)
+			}
+			// Resolved again on each dial of NSMgr
+			log.FromContext(ctx).Warn(err.Error())
+		}
+		// vsock.ContextDialer dials the unix and tcp addresses of the fallbacks too
+		contextDialer = srvDialer.ContextDialer(vsock.ContextDialer)
+	}
+	if contextDialer != nil {
+		dialOptions = append(dialOptions, grpc.WithContextDialer(contextDialer))
+	}
+	dialOptions = append(dialOptions, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+		Time:                config.KeepaliveTime,
+		Timeout:             config.KeepaliveTimeout,
+		PermitWithoutStream: config.KeepalivePermitWithoutStream,
+	}))
 
-	var healOptions = []heal.Option{heal.WithLivenessCheckInterval(config.LivenessCheckInterval),
-		heal.WithLivenessCheckTimeout(config.LivenessCheckTimeout)}
-
-	if config.LivenessCheckEnabled {
-		healOptions = append(healOptions, heal.WithLivenessCheck(vppheal.VPPLivenessCheck(vppConn)))
+	var statsCollector *ifstats.Collector
+	if interval := config.interfaceStatsInterval(); interval > 0 {
+		statsCollector = ifstats.NewCollector(ctx, config.VppStatsSocket, interval)
+	}
+	var statusWriter *statusfile.Writer
+	if config.StatusFile != "" {
+		statusWriter = statusfile.NewWriter(config.StatusFile)
+	}
+	var interfacesWriter *interfacesfile.Writer
+	if config.InterfacesFile != "" {
+		interfacesWriter = interfacesfile.NewWriter(config.InterfacesFile)
+	}
+	var preferredIPs *preferredip.File
+	if config.PreferredIPFile != "" {
+		if preferredIPs, err = preferredip.Load(config.PreferredIPFile); err != nil {
+			return withExitCode(exitConfig, err)
+		}
+	}
+	var localMonitor *localmonitor.Server
+	if config.MonitorSocket != "" {
+		localMonitor = localmonitor.NewServer(ctx)
+		if err = localMonitor.ListenAndServe(ctx, config.MonitorSocket); err != nil {
+			return withExitCode(exitConfig, err)
+		}
 	}
 
-	nsmClient := client.NewClient(
-		ctx,
-		client.WithClientURL(&config.ConnectTo),
-		client.WithName(config.Name),
-		client.WithHealClient(heal.NewClient(ctx, healOptions...)),
-		client.WithAdditionalFunctionality(
-			clientinfo.NewClient(),
-			upstreamrefresh.NewClient(ctx),
-			up.NewClient(ctx, vppConn),
-			connectioncontext.NewClient(vppConn),
-			memif.NewClient(ctx, vppConn),
-			sendfd.NewClient(),
-			excludedprefixes.NewClient(excludedprefixes.WithAwarenessGroups(config.AwarenessGroups))),
-		client.WithDialTimeout(config.DialTimeout),
-		client.WithDialOptions(dialOptions...),
-	)
+	var eventLogger *eventlog.Logger
+	if config.LogConnectionEvents {
+		eventLogger = eventlog.NewLogger()
+	}
 
-	nsmClient = retry.NewClient(nsmClient, retry.WithTryTimeout(config.RequestTimeout))
+	// Each NSMgr gets its own chain, the failover client sends the requests to the one currently used
+	nsmgrURLs := []*url.URL{&config.ConnectTo}
+	for i := range config.ConnectToFallbacks {
+		nsmgrURLs = append(nsmgrURLs, &config.ConnectToFallbacks[i])
+	}
+	nsmgrSelector := failover.NewSelector(nsmgrURLs...)
+	// The NSURL parameters set the try timeout and the ping probe of the connections to their network service, the
+	// settings are updated when the network services are reloaded
+	settings := newServiceSettings()
+	var capture *pcap.Capture
+	if config.PacketCapture != "" {
+		capture = pcap.NewCapture(config.PacketCapture, config.PacketCaptureMaxPackets, config.PacketCaptureDuration,
+			settings.capture)
+	}
+	// The limit is shared by the chains of all NSMgrs and VPP instances
+	inFlight := inflight.NewLimiter(config.MaxInFlightRequests)
+	var apiTrace *vpptrace.Trace
+	if config.VppAPITraceSize > 0 {
+		apiTrace = vpptrace.NewTrace(config.VppAPITraceSize, config.VppAPITraceDir)
+	}
+	// The chains are bound to a VPP instance, so they are created again if VPP is restarted
+	newClient := func(vpp *vppProcess) networkservice.NetworkServiceClient {
+		var nsmClients []networkservice.NetworkServiceClient
+		for _, u := range nsmgrURLs {
+			nsmClients = append(nsmClients, newNSMClient(vpp.ctx, config, u, vpp.conn, statsCollector, statusWriter, interfacesWriter, localMonitor, eventLogger, settings.probe, settings.tenant, settings.dscp, capture, preferredIPs, inFlight, apiTrace, dialOptions))
+		}
+		return retry.NewClient(failover.NewClient(nsmgrSelector, nsmClients...),
+			retry.WithTryTimeout(config.RequestTimeout),
+			retry.WithTryTimeouts(settings.tryTimeout),
+			retry.WithInterval(config.RetryInterval),
+			retry.WithMaxInterval(config.RetryMaxInterval),
+			retry.WithMultiplier(config.RetryMultiplier),
+			retry.WithJitter(config.RetryJitter),
+			retry.WithMaxRetries(config.RetryMaxRetries),
+			retry.WithMaxRetriesOf(settings.maxRetriesOf))
+	}
+	nsmClient := newClient(vpp)
 
 	// ********************************************************************************
 	// Configure signal handling context
 	// ********************************************************************************
-	signalCtx, cancelSignalCtx := notifyContext(ctx)
+	signalCtx, cancelSignalCtx := notifyContext(ctx, config.NetworkServicesFile == "", !config.StateDump)
 	defer cancelSignalCtx()
+	// SIGHUP reloads the network services file instead of stopping the client if it is set
+	reloadCh := make(chan os.Signal, 1)
+	if config.NetworkServicesFile != "" {
+		signal.Notify(reloadCh, syscall.SIGHUP)
+		defer signal.Stop(reloadCh)
+	}
+	// SIGQUIT dumps the state instead of stopping the client if the state dump is enabled, the dump waits for the
+	// startup to complete
+	dumpCh := make(chan os.Signal, 1)
+	if config.StateDump {
+		signal.Notify(dumpCh, syscall.SIGQUIT)
+		defer signal.Stop(dumpCh)
+	}
 
 	// ********************************************************************************
 	// Create Network Service Manager monitorClient
 	// ********************************************************************************
-	dialCtx, cancelDial := context.WithTimeout(signalCtx, config.DialTimeout)
-	defer cancelDial()
-
 	log.FromContext(ctx).Infof("NSC: Connecting to Network Service Manager %v", config.ConnectTo.String())
-	cc, err := grpc.DialContext(dialCtx, grpcutils.URLToTarget(&config.ConnectTo), dialOptions...)
+	cc, err := nsmgrSelector.Dial(signalCtx, config.monitorDialTimeout(), dialOptions...)
+	for interval := config.RetryInterval; err != nil && config.WaitForNSMgr && signalCtx.Err() == nil; interval = nextRetryInterval(config, interval) {
+		log.FromContext(ctx).Warnf("failed dial to NSMgr, trying again in %s: %s", interval, err.Error())
+		select {
+		case <-signalCtx.Done():
+		case <-time.After(interval):
+			cc, err = nsmgrSelector.Dial(signalCtx, config.monitorDialTimeout(), dialOptions...)
+		}
+	}
+	if err != nil && signalCtx.Err() != nil {
+		log.FromContext(ctx).Info("shutdown is requested while dialing NSMgr")
+		return nil
+	}
 	if err != nil {
-		log.FromContext(ctx).Fatalf("failed dial to NSMgr: %v", err.Error())
+		return withExitCode(exitDial, errors.Wrap(err, "failed dial to NSMgr"))
 	}
 
 	monitorClient := networkservice.NewMonitorConnectionClient(cc)
+	var connRecorder *connmetrics.Recorder
+	if metrics.Enabled() {
+		connRecorder = connmetrics.NewRecorder(settings.tenant)
+	}
+	var healthLogger *healthlog.Logger
+	if config.HealthLogInterval > 0 {
+		healthLogger = healthlog.NewLogger(statsCollector)
+		go healthLogger.Run(signalCtx, config.HealthLogInterval)
+	}
+	allConnected := allconnected.NewNotifier(config.AllConnectedFile)
+	var connWatchdog *watchdog.Watchdog
+	if config.EnableWatchdog {
+		connWatchdog = watchdog.NewWatchdog(config.WatchdogThreshold)
+	}
+	// The watchers follow the monitor connection, they are stopped and started again when it is dialed to another
+	// NSMgr
+	startWatchers := func(monitorClient networkservice.MonitorConnectionClient) (stop func()) {
+		watchCtx, cancelWatch := context.WithCancel(signalCtx)
+		var wg sync.WaitGroup
+		watch := func(w func(context.Context, networkservice.MonitorConnectionClient, string)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				w(watchCtx, monitorClient, config.Name+"-")
+			}()
+		}
+		if eventLogger != nil {
+			watch(eventLogger.Watch)
+		}
+		if connRecorder != nil {
+			watch(connRecorder.Watch)
+		}
+		if healthLogger != nil {
+			watch(healthLogger.Watch)
+		}
+		watch(allConnected.Watch)
+		if connWatchdog != nil {
+			watch(connWatchdog.Watch)
+		}
+		return func() {
+			cancelWatch()
+			wg.Wait()
+		}
+	}
+	stopWatchers := startWatchers(monitorClient)
+	defer func() {
+		stopWatchers()
+	}()
+	files := &discoveryFiles{
+		statusWriter:     statusWriter,
+		interfacesWriter: interfacesWriter,
+		allConnected:     allConnected,
+	}
+	defer files.remove(ctx)
+	go files.removeOnShutdown(ctx, signalCtx)
+	// Stays nil if the watchdog is disabled, so no recovery is ever received
+	var recoveries <-chan string
+	if connWatchdog != nil {
+		recoveries = connWatchdog.Recoveries()
+	}
+	var idles <-chan string
+	if config.IdleTimeout > 0 {
+		idleDetector := idle.NewDetector(statsCollector, config.IdleTimeout, config.interfaceStatsInterval())
+		idles = idleDetector.Idle()
+		go idleDetector.Watch(signalCtx)
+	}
+	var reconcileTicks <-chan time.Time
+	if config.ReconcileInterval > 0 {
+		reconcileTicker := time.NewTicker(config.ReconcileInterval)
+		defer reconcileTicker.Stop()
+		reconcileTicks = reconcileTicker.C
+	}
+	var linkChanges <-chan []string
+	if len(config.WatchInterfaces) > 0 {
+		linkWatcher, watchErr := hostlink.NewWatcher(config.WatchInterfaces, config.WatchInterfacesSettle)
+		if watchErr != nil {
+			return withExitCode(exitFailure, errors.Wrap(watchErr, "failed to watch the interfaces"))
+		}
+		linkChanges = linkWatcher.Changes()
+		go linkWatcher.Watch(signalCtx)
+	}
 
 	// ********************************************************************************
 	log.FromContext(ctx).Infof("executing phase 5: connect to all passed services (time since start: %s)", time.Since(starttime))
 	// ********************************************************************************
+	startup.setPhase("phase 5: connect to all passed services")
+
+	if config.CleanupStaleConnections {
+		var ids []string
+		for i := range config.NetworkServices {
+			ids = append(ids, config.serviceConnectionID(idSuffix, i))
+		}
+		closeStaleConnections(ctx, signalCtx, config.Name, ids, monitorClient, nsmClient, config.RequestTimeout)
+	}
+
+	if config.InitialDelay > 0 {
+		// The requests see the cancelled signalCtx and return at once on shutdown
+		log.FromContext(ctx).Infof("waiting %s for the control plane to settle before the first request", config.InitialDelay)
+		select {
+		case <-signalCtx.Done():
+		case <-time.After(config.InitialDelay):
+		}
+	}
+
+	rotations := newRotations()
+	defer rotations.stopAll()
+	connections, err := requestConnections(ctx, signalCtx, config, idSuffix, config.resumePolicy(true), monitorClient,
+		nsmClient, settings, rotations)
+	if err != nil {
+		return withExitCode(exitRequest, err)
+	}
+	startup.done()
+	log.FromContext(ctx).Infof("completed phase 5: connect to all passed services (time since start: %s)", time.Since(starttime))
+
+	// ********************************************************************************
+	// Wait for the shutdown, close the connections and restart VPP if it dies meanwhile, reconcile the connections
+	// with the network services file on SIGHUP, drain and resume them on the admin requests
+	// ********************************************************************************
+	vppDead, drained := false, false
+	// Set if the client exits on a change of the watched interfaces
+	var linkChangeErr error
+	for !vppDead && signalCtx.Err() == nil {
+		// Each iteration follows a change of the established connections
+		allConnected.SetExpected(ctx, connections.list(), len(config.NetworkServices))
+		select {
+		case <-signalCtx.Done():
+			continue
+		case <-reloadCh:
+			if !reloadNetworkServices(ctx, config) {
+				continue
+			}
+			effectiveConfig.Store(config.effective())
+			// The indexes of the network services change on reload, the idle ones are requested again
+			connections.clearIdle()
+			// The drained connections are requested with the reloaded network services on resume
+			if !drained {
+				reconcileConnections(ctx, signalCtx, config, idSuffix, monitorClient, nsmClient, connections, settings, rotations)
+			}
+			continue
+		case id := <-recoveries:
+			if connections.load(id) == nil || !connWatchdog.Down(id) {
+				// Closed by the client or up again meanwhile
+				connWatchdog.Forget(id)
+				continue
+			}
+			recoverConnection(ctx, signalCtx, config, id, monitorClient, nsmClient, connections, settings, rotations)
+			continue
+		case <-nsmgrSelector.Switches():
+			stopWatchers()
+			if redialed, redialErr := nsmgrSelector.Redial(signalCtx, dialOptions...); redialErr == nil {
+				log.FromContext(ctx).Infof("monitor connection is dialed again to NSMgr %s", redialed.Target())
+				monitorClient = networkservice.NewMonitorConnectionClient(redialed)
+			} else {
+				log.FromContext(ctx).Errorf("failed to dial the monitor connection again: %s", redialErr.Error())
+			}
+			stopWatchers = startWatchers(monitorClient)
+			continue
+		case id := <-rotations.due():
+			rotateConnection(ctx, signalCtx, config, id, monitorClient, nsmClient, connections, settings, rotations)
+			continue
+		case <-dumpCh:
+			dumpState(ctx, config, starttime, connections.list(), vpp.conn, lastErrors)
+			continue
+		case id := <-idles:
+			closeIdleConnection(ctx, config, id, nsmClient, connections, settings, rotations)
+			if connWatchdog != nil {
+				connWatchdog.Forget(id)
+			}
+			continue
+		case <-reconcileTicks:
+			if !drained {
+				reconcileDown(ctx, signalCtx, config, idSuffix, monitorClient, nsmClient, connections, settings, rotations)
+			}
+			continue
+		case trustDomain := <-trustDomainChanges:
+			if !drained {
+				requestConnectionsForTrustDomain(ctx, signalCtx, config, trustDomain, monitorClient, nsmClient, connections, settings, rotations)
+			}
+			continue
+		case changed := <-linkChanges:
+			if config.WatchInterfacesAction == watchActionExit {
+				linkChangeErr = withExitCode(exitFailure, errors.Errorf("watched interfaces %s changed", strings.Join(changed, ", ")))
+				cancelSignalCtx()
+				continue
+			}
+			if !drained {
+				requestConnectionsAgain(ctx, signalCtx, config, changed, monitorClient, nsmClient, connections, settings, rotations)
+			}
+			continue
+		case request := <-drains.requests:
+			switch {
+			case request.drain && !drained:
+				drains.setState(drainStateDraining)
+				close(request.started)
+				log.FromContext(ctx).Info("draining all connections")
+				rotations.stopAll()
+				closeConnections(ctx, config, nsmClient, connections.list(), config.GracefulShutdownTimeout)
+				connections = newConnectionStore()
+				drained = true
+				drains.setState(drainStateDrained)
+			case !request.drain && drained:
+				drains.setState(drainStateResuming)
+				close(request.started)
+				log.FromContext(ctx).Info("resuming all connections")
+				reconcileConnections(ctx, signalCtx, config, idSuffix, monitorClient, nsmClient, connections, settings, rotations)
+				drained = false
+				drains.setState(drainStateActive)
+			default:
+				close(request.started)
+			}
+			continue
+		case <-vpp.died:
+		}
+		log.FromContext(ctx).Warn("VPP has died, closing all connections toward NSMgr")
+		rotations.stopAll()
+		closeConnections(ctx, config, nsmClient, connections.list(), vppDeathCloseTimeout)
+		vpp.stop()
+		if !config.RestartVppOnFailure {
+			vppDead = true
+			continue
+		}
+
+		restartStart := time.Now()
+		restarted, restartErr := restarter.restart(ctx, signalCtx, vpp, vppOptions...)
+		if restartErr != nil {
+			if signalCtx.Err() != nil {
+				vppDead = true
+				continue
+			}
+			return withExitCode(exitVpp, errors.Wrap(restartErr, "failed to restart VPP"))
+		}
+		vpp = restarted
+		nsmClient = newClient(vpp)
+		if drained {
+			log.FromContext(ctx).Info("VPP is restarted, the connections are drained")
+			continue
+		}
+		log.FromContext(ctx).Info("VPP is restarted, requesting all connections again")
+		if connections, err = requestConnections(ctx, signalCtx, config, idSuffix, config.resumePolicy(false),
+			monitorClient, nsmClient, settings, rotations); err != nil {
+			return withExitCode(exitRequest, err)
+		}
+		log.FromContext(ctx).WithField("duration", time.Since(restartStart)).Info("all connections are requested again after VPP restart")
+	}
+
+	// ********************************************************************************
+	// Close all connections before VPP is torn down
+	// ********************************************************************************
+	if !vppDead {
+		rotations.stopAll()
+		closeConnections(ctx, config, nsmClient, connections.list(), config.GracefulShutdownTimeout)
+	}
+	files.remove(ctx)
+	if vppDead {
+		return withExitCode(exitVpp, errors.New("VPP has died"))
+	}
+	return linkChangeErr
+}
+
+// requestConnections - requests the connections to all network services, the connections are rotated until
+// signalCtx is done if MaxConnectionLifetime is set. If a request fails, the error is returned with the connections
+// established so far, or with WaitForNSMgr the established connections are closed and the whole set is requested again
+// with backoff until signalCtx is done. The request in progress is cancelled if signalCtx is done, the connections
+// established so far are returned then.
+func requestConnections(ctx, signalCtx context.Context, config *Config, idSuffix, resumePolicy string,
+	monitorClient networkservice.MonitorConnectionClient, nsmClient networkservice.NetworkServiceClient,
+	settings *serviceSettings, rotations *rotations) (*connectionStore, error) {
+	interval := config.RetryInterval
+	for {
+		connections, err := requestAll(ctx, signalCtx, config, idSuffix, resumePolicy, monitorClient, nsmClient, settings)
+		if err == nil {
+			if config.MaxConnectionLifetime > 0 {
+				for _, conn := range connections.list() {
+					rotations.start(signalCtx, conn.GetId(), config.MaxConnectionLifetime)
+				}
+			}
+			return connections, nil
+		}
+		if signalCtx.Err() != nil {
+			// The established connections are closed on shutdown
+			log.FromContext(ctx).Infof("shutdown is requested while requesting connections, %d of %d are established",
+				len(connections.list()), len(config.NetworkServices))
+			return connections, nil
+		}
+		if !config.WaitForNSMgr {
+			return connections, errors.Wrap(err, "request has failed")
+		}
 
+		log.FromContext(ctx).Warnf("failed to request all connections, requesting them again in %s: %s", interval, err.Error())
+		closeConnections(ctx, config, nsmClient, connections.list(), config.GracefulShutdownTimeout)
+		select {
+		case <-signalCtx.Done():
+			return newConnectionStore(), nil
+		case <-time.After(interval):
+		}
+		interval = nextRetryInterval(config, interval)
+	}
+}
+
+// requestAll - requests the connections to all network services one by one, returns the established connections
+func requestAll(ctx, signalCtx context.Context, config *Config, idSuffix, resumePolicy string,
+	monitorClient networkservice.MonitorConnectionClient, nsmClient networkservice.NetworkServiceClient,
+	settings *serviceSettings) (*connectionStore, error) {
+	connections := newConnectionStore()
 	for i := 0; i < len(config.NetworkServices); i++ {
-		u := nsurl.NSURL(config.NetworkServices[i])
-
-		id := fmt.Sprintf("%s-%d", config.Name, i)
-		var monitoredConnections map[string]*networkservice.Connection
-		monitorCtx, cancelMonitor := context.WithTimeout(signalCtx, config.RequestTimeout)
-		defer cancelMonitor()
-
-		stream, err := monitorClient.MonitorConnections(monitorCtx, &networkservice.MonitorScopeSelector{
-			PathSegments: []*networkservice.PathSegment{
-				{
-					Id: id,
-				},
-			},
-		})
+		id := config.serviceConnectionID(idSuffix, i)
+		resp, err := requestConnection(ctx, signalCtx, config, i, id, resumePolicy, monitorClient, nsmClient, settings)
 		if err != nil {
-			log.FromContext(ctx).Fatalf("error from monitorConnectionClient", err.Error())
+			return connections, err
 		}
+		connections.add(i, config.NetworkServices[i].String(), resp)
+	}
+	return connections, nil
+}
 
-		event, err := stream.Recv()
+// requestConnection - requests the connection with the id to the index-th network service, resuming it if it is
+// still known to NSMgr and resumePolicy allows it.
+func requestConnection(ctx, signalCtx context.Context, config *Config, index int, id, resumePolicy string,
+	monitorClient networkservice.MonitorConnectionClient, nsmClient networkservice.NetworkServiceClient,
+	settings *serviceSettings) (*networkservice.Connection, error) {
+	u := nsurl.NSURL(config.NetworkServices[index])
+	if mech := u.Mechanism(); !supportedMechanism(mech.Type) {
+		return nil, errors.Errorf("mechanism type: %v is not supported", mech.Type)
+	}
+	settings.set(config, index, id)
+	request := newRequest(config, index, id)
+	if len(config.MechanismParameters) > 0 {
+		mech := request.GetMechanismPreferences()[0]
+		log.FromContext(ctx).WithField("id", id).Infof("%s mechanism parameters: %v", mech.GetType(), mech.GetParameters())
+	}
+	var monitorDuration time.Duration
+	if resumePolicy != resumePolicyNever {
+		monitorStart := time.Now()
+		resumed, err := resumeConnection(ctx, signalCtx, monitorClient, request, config.requestTimeout(index))
+		monitorDuration = time.Since(monitorStart)
 		if err != nil {
-			log.FromContext(ctx).Errorf("error from monitorConnection stream", err.Error())
-		} else {
-			monitoredConnections = event.Connections
+			return nil, err
 		}
-		cancelMonitor()
+		if !resumed && resumePolicy == resumePolicyRequire {
+			return nil, errors.Errorf("connection %s to %s is not known to NSMgr, it is required to resume it", id,
+				u.NetworkService())
+		}
+		if !resumed && config.NetworkServices[index].Query().Get(connectionIDParam) != "" {
+			log.FromContext(ctx).WithField("id", id).Warnf("connection ID set by %s parameter is not known to NSMgr, "+
+				"requesting the connection from scratch", connectionIDParam)
+		}
+	}
 
-		mech := u.Mechanism()
-		if mech.Type != memif.MECHANISM {
-			log.FromContext(ctx).Fatalf("mechanism type: %v is not supported", mech.Type)
+	// The request is cancelled on shutdown, the chain elements undo what they have done in VPP on the failed request
+	// with the postponed contexts, so no interface is left behind
+	spanAttrs := []attribute.KeyValue{spans.NetworkServiceKey.String(u.NetworkService()), spans.ConnectionIDKey.String(id)}
+	if t := config.tenant(index); t != "" {
+		spanAttrs = append(spanAttrs, spans.TenantKey.String(t))
+	}
+	requestCtx, span := spans.Start(signalCtx, "request", spanAttrs...)
+	requestCtx, timings := phases.WithTimings(requestCtx)
+	var unpinned *networkservice.NetworkServiceRequest
+	if nse := request.GetConnection().GetNetworkServiceEndpointName(); nse != "" && config.PinnedNSEFallback {
+		unpinned = request.Clone()
+		unpinned.GetConnection().NetworkServiceEndpointName = ""
+	}
+	resp, err := nsmClient.Request(requestCtx, request)
+	if err != nil && unpinned != nil && signalCtx.Err() == nil {
+		log.FromContext(ctx).WithField("id", id).Warnf("request to the pinned NSE %s has failed, requesting any NSE of %s: %s",
+			request.GetConnection().GetNetworkServiceEndpointName(), u.NetworkService(), err.Error())
+		resp, err = nsmClient.Request(requestCtx, unpinned)
+	}
+	if err != nil {
+		spans.End(span, err)
+		if signalCtx.Err() != nil {
+			return nil, errors.Wrapf(signalCtx.Err(), "request of connection %s is cancelled", id)
+		}
+		return nil, errors.Wrapf(err, "request of connection %s has failed", id)
+	}
+	span.SetAttributes(spans.MechanismKey.String(resp.GetMechanism().GetType()))
+	spans.End(span, nil)
+
+	durations := timings.Durations()
+	if resumePolicy != resumePolicyNever {
+		durations[phases.Monitor] = monitorDuration
+	}
+	phases.Record(ctx, u.NetworkService(), config.tenant(index), durations)
+	logger := log.FromContext(ctx).WithField("id", resp.GetId())
+	for _, phase := range []string{phases.Monitor, phases.Dial, phases.Request, phases.Interface} {
+		if duration, ok := durations[phase]; ok {
+			logger = logger.WithField(phase, duration)
 		}
-		request := &networkservice.NetworkServiceRequest{
-			Connection: &networkservice.Connection{
-				Id:             id,
-				NetworkService: u.NetworkService(),
-				Labels:         u.Labels(),
+	}
+	logger.Infof("connection is established, MTU: %d, payload: %s", resp.GetContext().GetMTU(), payloadOf(resp))
+
+	return resp, nil
+}
+
+// newUpstreamRefreshClient - returns the client refreshing the connections on the upstream refresh requests from NSMgr,
+// or the null client if the upstream refresh is disabled
+func newUpstreamRefreshClient(ctx context.Context, config *Config) networkservice.NetworkServiceClient {
+	if !config.EnableUpstreamRefresh {
+		return null.NewClient()
+	}
+	var upstreamRefreshOptions []upstreamrefresh.Option
+	if config.UpstreamRefreshLocal {
+		upstreamRefreshOptions = append(upstreamRefreshOptions, upstreamrefresh.WithLocalNotifications())
+	}
+	return upstreamrefresh.NewClient(ctx, upstreamRefreshOptions...)
+}
+
+// newNSMClient - returns a client with the VPP chain elements connected to the NSMgr at connectTo
+func newNSMClient(ctx context.Context, config *Config, connectTo *url.URL, vppConn api.Connection,
+	statsCollector *ifstats.Collector, statusWriter *statusfile.Writer, interfacesWriter *interfacesfile.Writer,
+	localMonitor *localmonitor.Server, eventLogger *eventlog.Logger, probes func(connectionID string) *pingprobe.Probe,
+	tenants func(connectionID string) string, dscps func(connectionID string) int, capture *pcap.Capture, preferredIPs *preferredip.File, inFlight *inflight.Limiter, apiTrace *vpptrace.Trace,
+	dialOptions []grpc.DialOption) networkservice.NetworkServiceClient {
+	var healOptions = []heal.Option{heal.WithLivenessCheckInterval(config.LivenessCheckInterval),
+		heal.WithLivenessCheckTimeout(config.LivenessCheckTimeout)}
+
+	if config.LivenessCheckEnabled {
+		healOptions = append(healOptions, heal.WithLivenessCheck(vppheal.VPPLivenessCheck(vppConn)))
+	}
+	healClient := null.NewClient()
+	if config.EnableHeal {
+		healClient = heal.NewClient(ctx, healOptions...)
+	}
+
+	additionalFunctionality := []networkservice.NetworkServiceClient{
+		phases.NewDialedClient(),
+		tenant.NewClient(tenants),
+		clientinfo.NewClient(config.clientInfoEnvs()),
+		kernelname.NewClient(),
+	}
+	if apiTrace != nil {
+		// The liveness check is not traced, so its periodic calls don't push the calls of the requests out of the trace
+		vppConn = apiTrace.Connection(vppConn)
+		additionalFunctionality = append(additionalFunctionality, vpptrace.NewClient(apiTrace))
+	}
+	if eventLogger != nil {
+		additionalFunctionality = append(additionalFunctionality, eventlog.NewClient(eventLogger))
+	}
+	if config.LogConnectionPath {
+		additionalFunctionality = append(additionalFunctionality, pathlog.NewClient())
+	}
+	additionalFunctionality = append(additionalFunctionality, newUpstreamRefreshClient(ctx, config))
+	if config.IPFamily != "" {
+		additionalFunctionality = append(additionalFunctionality, ipfamily.NewClient(config.IPFamily))
+	}
+	if preferredIPs != nil {
+		additionalFunctionality = append(additionalFunctionality, preferredip.NewClient(preferredIPs))
+	}
+	if statsCollector != nil {
+		additionalFunctionality = append(additionalFunctionality, ifstats.NewClient(statsCollector))
+	}
+	// DSCP can be set per network service, so the policer is always in the chain and skips the connections it has
+	// nothing to do for
+	additionalFunctionality = append(additionalFunctionality, policer.NewClient(vppConn, config.BandwidthMbps, dscps))
+	if statusWriter != nil {
+		additionalFunctionality = append(additionalFunctionality, statusfile.NewClient(vppConn, statusWriter))
+	}
+	if interfacesWriter != nil {
+		additionalFunctionality = append(additionalFunctionality, interfacesfile.NewClient(vppConn, interfacesWriter))
+	}
+	if localMonitor != nil {
+		additionalFunctionality = append(additionalFunctionality, localmonitor.NewClient(localMonitor))
+	}
+	// The network services with the ping parameter may be added on reload, so the probe is always in the chain and
+	// skips the connections with no probe
+	additionalFunctionality = append(additionalFunctionality, pingprobe.NewClient(vppConn, probes))
+	if capture != nil {
+		additionalFunctionality = append(additionalFunctionality, pcap.NewClient(vppConn, capture))
+	}
+	additionalFunctionality = append(additionalFunctionality, closecheck.NewClient(vppConn))
+	if config.LinkUpTimeout > 0 {
+		additionalFunctionality = append(additionalFunctionality, linkup.NewClient(vppConn, config.LinkUpTimeout))
+	}
+	additionalFunctionality = append(additionalFunctionality,
+		none.NewClient(
+			vl3.NewClient(vppConn),
+			up.NewClient(ctx, vppConn),
+			macaddr.NewClient(vppConn),
+			connectioncontext.NewClient(vppConn),
+			staticroutes.NewClient(config.Routes),
+			memif.NewClient(ctx, memifrole.NewConnection(memifsize.NewConnection(vppConn, config.MemifRingSize, config.MemifBufferSize))),
+			memifsocket.NewClient(config.MemifSocketDir),
+			memifrole.NewClient(config.MemifRole),
+			vlan.NewClient(vppConn, config.VlanDevices),
+		),
+		phases.NewNSMgrClient(),
+		sendfd.NewClient(),
+	)
+	if config.EnableExcludedPrefixes {
+		if config.ExcludedPrefixesFile != "" {
+			// Should go before excludedprefixes client, so the prefixes from the file are combined with awareness groups
+			additionalFunctionality = append(additionalFunctionality, excludedprefixesfile.NewClient(ctx, config.ExcludedPrefixesFile))
+		}
+		additionalFunctionality = append(additionalFunctionality,
+			prefixcollision.NewClient(excludedprefixes.NewClient(excludedprefixes.WithAwarenessGroups(config.AwarenessGroups))))
+	}
+	additionalFunctionality = append(additionalFunctionality, inflight.NewClient(inFlight))
+
+	return client.NewClient(
+		ctx,
+		client.WithClientURL(connectTo),
+		client.WithName(config.Name),
+		client.WithHealClient(healClient),
+		client.WithAdditionalFunctionality(additionalFunctionality...),
+		client.WithDialTimeout(config.DialTimeout),
+		client.WithDialOptions(dialOptions...),
+	)
+}
+
+// monitorSettle - how long to wait for more events of the initial state from the monitor stream
+const monitorSettle = 100 * time.Millisecond
+
+// resumeConnection - looks for the connection of the request in NSMgr, so it is resumed after restart instead of
+// creating a new one. Returns true if the connection is found.
+func resumeConnection(ctx, signalCtx context.Context, monitorClient networkservice.MonitorConnectionClient,
+	request *networkservice.NetworkServiceRequest, timeout time.Duration) (bool, error) {
+	id := request.GetConnection().GetId()
+	mechType := request.GetMechanismPreferences()[0].GetType()
+
+	_, span := spans.Start(ctx, "monitor-resume",
+		spans.NetworkServiceKey.String(request.GetConnection().GetNetworkService()),
+		spans.ConnectionIDKey.String(id))
+	defer span.End()
+
+	monitorCtx, cancelMonitor := context.WithTimeout(signalCtx, timeout)
+	defer cancelMonitor()
+
+	stream, err := monitorClient.MonitorConnections(monitorCtx, &networkservice.MonitorScopeSelector{
+		PathSegments: []*networkservice.PathSegment{
+			{
+				Id: id,
 			},
-			MechanismPreferences: []*networkservice.Mechanism{
-				mech,
+		},
+	})
+	if err != nil {
+		span.RecordError(err)
+		return false, errors.Wrap(err, "error from monitorConnectionClient")
+	}
+
+	conns, err := connwatch.InitialState(monitorCtx, stream, monitorSettle)
+	if err != nil {
+		log.FromContext(ctx).Errorf("error from monitorConnection stream: %s", err.Error())
+		span.RecordError(err)
+		return false, nil
+	}
+
+	for _, conn := range conns {
+		path := conn.GetPath()
+		if path.Index == 1 && path.PathSegments[0].Id == id && conn.Mechanism.Type == mechType {
+			request.Connection = conn
+			request.Connection.Path.Index = 0
+			request.Connection.Id = id
+			span.SetAttributes(spans.MechanismKey.String(conn.GetMechanism().GetType()))
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// newRequest - returns a request for the index-th network service, the connection ID is set to id
+func newRequest(config *Config, index int, id string) *networkservice.NetworkServiceRequest {
+	u := (*nsurl.NSURL)(&config.NetworkServices[index])
+
+	mech := u.Mechanism()
+	if len(config.MechanismParameters) > 0 && mech.GetType() != none.MECHANISM {
+		mech.Parameters = mergeMaps(config.MechanismParameters, mech.GetParameters())
+	}
+	if name := config.interfaceName(index); name != "" {
+		if mech.GetParameters() == nil {
+			mech.Parameters = make(map[string]string)
+		}
+		mech.GetParameters()[common.InterfaceNameKey] = name
+	}
+
+	if vlanID := config.NetworkServices[index].Query().Get(vlanmech.ID); vlanID != "" && mech.GetType() == vlanmech.MECHANISM {
+		if mech.GetParameters() == nil {
+			mech.Parameters = make(map[string]string)
+		}
+		mech.GetParameters()[vlanmech.ID] = vlanID
+	}
+
+	if mech.GetType() == memifmech.MECHANISM {
+		if mech.GetParameters() == nil && (config.MemifRingSize != 0 || config.MemifBufferSize != 0 || config.MemifRole != memifrole.Auto) {
+			mech.Parameters = make(map[string]string)
+		}
+		if config.MemifRingSize != 0 {
+			mech.GetParameters()[memifsize.RingSizeKey] = strconv.FormatUint(uint64(config.MemifRingSize), 10)
+		}
+		if config.MemifBufferSize != 0 {
+			mech.GetParameters()[memifsize.BufferSizeKey] = strconv.FormatUint(uint64(config.MemifBufferSize), 10)
+		}
+		if config.MemifRole != memifrole.Auto {
+			mech.GetParameters()[memifrole.Key] = config.MemifRole
+		}
+	}
+
+	labels := mergeMaps(config.topologyLabels(), config.ConnectionLabels, u.Labels())
+	for _, param := range nonLabelParams {
+		delete(labels, param)
+	}
+	extraContext := mergeMaps(config.ExtraContext)
+	for key, value := range labels {
+		if strings.HasPrefix(key, extraContextParamPrefix) {
+			extraContext[strings.TrimPrefix(key, extraContextParamPrefix)] = value
+			delete(labels, key)
+		}
+	}
+
+	var payloadType string
+	if mech.GetType() != none.MECHANISM {
+		payloadType = config.Payload
+	}
+
+	var ethernetContext *networkservice.EthernetContext
+	if mac := config.sourceMAC(index); mac != "" {
+		ethernetContext = &networkservice.EthernetContext{
+			SrcMac: mac,
+		}
+	}
+
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:                         id,
+			NetworkService:             u.NetworkService(),
+			NetworkServiceEndpointName: config.NetworkServices[index].Query().Get(nseParam),
+			Payload:                    payloadType,
+			Labels:                     labels,
+			Context: &networkservice.ConnectionContext{
+				MTU:             config.InterfaceMTU,
+				EthernetContext: ethernetContext,
+				ExtraContext:    extraContext,
 			},
+		},
+		MechanismPreferences: []*networkservice.Mechanism{
+			mech,
+		},
+	}
+}
+
+// logMemifSizes - logs the tradeoff of the memif ring and buffer sizes if they differ from the VPP defaults
+func logMemifSizes(ctx context.Context, config *Config) {
+	if config.MemifRingSize != 0 && config.MemifRingSize != defaultMemifRingSize {
+		log.FromContext(ctx).Infof("Memif ring size is %d instead of %d: larger rings absorb bursts and raise the throughput "+
+			"at the cost of memory and queueing latency, smaller rings lower the latency but drop packets on bursts",
+			config.MemifRingSize, defaultMemifRingSize)
+	}
+	if config.MemifBufferSize != 0 && config.MemifBufferSize != defaultMemifBufferSize {
+		log.FromContext(ctx).Infof("Memif buffer size is %d instead of %d: larger buffers carry jumbo frames without chaining "+
+			"at the cost of memory, each queue takes ring size * buffer size bytes of the memif region",
+			config.MemifBufferSize, defaultMemifBufferSize)
+	}
+}
+
+// payloadOf - returns the effective payload of conn, the interface is created in IP mode unless ETHERNET is requested
+func payloadOf(conn *networkservice.Connection) string {
+	if conn.GetPayload() == payload.Ethernet || conn.GetMechanism().GetType() == none.MECHANISM {
+		return conn.GetPayload()
+	}
+	return payload.IP
+}
+
+// connectionID - returns the ID of the connection to the index-th network service, the ID has to be the same across
+// restarts to resume the connection
+func connectionID(name, suffix string, index int) string {
+	if suffix == "" {
+		return fmt.Sprintf("%s-%d", name, index)
+	}
+	return fmt.Sprintf("%s-%s-%d", name, suffix, index)
+}
+
+// nextRetryInterval - returns the interval to wait before the next attempt to reach NSMgr after waiting interval
+func nextRetryInterval(config *Config, interval time.Duration) time.Duration {
+	interval = time.Duration(float64(interval) * config.RetryMultiplier)
+	if interval > config.RetryMaxInterval {
+		return config.RetryMaxInterval
+	}
+	return interval
+}
+
+// mergeMaps - returns a new map containing all the entries of maps, the later maps take precedence on conflict
+func mergeMaps(maps ...map[string]string) map[string]string {
+	result := make(map[string]string)
+	for _, m := range maps {
+		for k, v := range m {
+			result[k] = v
 		}
+	}
+	return result
+}
+
+// spiffeCredentials - returns the TLS credentials and the token generator following the SVID of the SPIRE agent, and
+// the channel of the new trust domains of the SVID
+func spiffeCredentials(ctx context.Context, config *Config) (credentials.TransportCredentials, token.GeneratorFunc, <-chan spiffeid.TrustDomain, error) {
+	source, err := newX509Source(ctx, config.SpiffeSocketPath, config.SvidWaitTimeout)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "error getting x509 source")
+	}
+	svid, err := source.GetX509SVID()
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "error getting x509 svid")
+	}
+	logrus.Infof("SVID: %q", svid.ID)
 
-		for _, conn := range monitoredConnections {
-			path := conn.GetPath()
-			if path.Index == 1 && path.PathSegments[0].Id == id && conn.Mechanism.Type == u.Mechanism().Type {
-				request.Connection = conn
-				request.Connection.Path.Index = 0
-				request.Connection.Id = id
-				break
+	authorizer, err := config.spiffeAuthorizer(svid.ID.TrustDomain())
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "error creating SPIFFE authorizer")
+	}
+	if len(config.AuthorizedSpiffeIDs) > 0 {
+		logrus.Infof("Authorized SPIFFE IDs: %q", config.AuthorizedSpiffeIDs)
+	}
+	if len(config.FederatedTrustDomains) > 0 {
+		logrus.Infof("Federated trust domains: %q", config.FederatedTrustDomains)
+		// The federated bundles come from the workload API along with the SVID, a missing one may still be provided
+		// by SPIRE later
+		federated, _ := config.federatedTrustDomains()
+		for _, td := range federated {
+			if _, err := source.GetX509BundleForTrustDomain(td); err != nil {
+				logrus.Warnf("no bundle of federated trust domain %s yet: %s", td, err.Error())
 			}
 		}
-
-		resp, err := nsmClient.Request(ctx, request)
+	}
+	// The token generator gets the SVID from source for each token, so the tokens follow the rotations, only the
+	// authorizer depending on the trust domain of the SVID needs to be applied again
+	rotatingAuthorizer := svidrotation.NewAuthorizer(authorizer)
+	// The connections authenticated under the previous trust domain are requested again by the main loop, only the
+	// last change matters if it is behind
+	trustDomainChanges := make(chan spiffeid.TrustDomain, 1)
+	trustDomain := svid.ID.TrustDomain()
+	go svidrotation.Watch(ctx, source, svid, func(ctx context.Context, svid *x509svid.SVID) {
+		if svid.ID.TrustDomain() != trustDomain {
+			log.FromContext(ctx).Warnf("SVID trust domain has changed from %s to %s", trustDomain, svid.ID.TrustDomain())
+			trustDomain = svid.ID.TrustDomain()
+			select {
+			case <-trustDomainChanges:
+			default:
+			}
+			trustDomainChanges <- trustDomain
+		}
+		if len(config.AuthorizedSpiffeIDs) == 0 && len(config.FederatedTrustDomains) == 0 {
+			return
+		}
+		authorizer, err := config.spiffeAuthorizer(svid.ID.TrustDomain())
 		if err != nil {
-			log.FromContext(ctx).Fatalf("request has failed: %v", err.Error())
+			log.FromContext(ctx).Errorf("failed to apply SPIFFE authorizer again: %s", err.Error())
+			return
 		}
+		rotatingAuthorizer.Store(authorizer)
+		log.FromContext(ctx).Info("SPIFFE authorizer is applied again for the rotated SVID")
+	})
+	tlsClientConfig := tlsconfig.MTLSClientConfig(source, source, rotatingAuthorizer.Authorize)
+	// Both are validated in phase 1
+	tlsClientConfig.MinVersion, _ = config.tlsMinVersion()
+	tlsClientConfig.CipherSuites, _ = config.tlsCipherSuites()
+	if len(tlsClientConfig.CipherSuites) > 0 {
+		logrus.Infof("TLS cipher suites: %q", config.TLSCipherSuites)
+	}
 
-		defer func() {
-			closeCtx, cancelClose := context.WithTimeout(ctx, config.RequestTimeout)
-			defer cancelClose()
-			_, _ = nsmClient.Close(closeCtx, resp)
-		}()
+	return credentials.NewTLS(tlsClientConfig), spiffejwt.TokenGeneratorFunc(source, config.MaxTokenLifetime), trustDomainChanges, nil
+}
+
+const (
+	svidMinRetryInterval = time.Second
+	svidMaxRetryInterval = 10 * time.Second
+)
+
+// newX509Source - returns the X509 source once the SPIRE agent provides the first SVID, the tries are repeated with
+// backoff for up to timeout as the agent may be not ready yet on pod start. The workload API is served on socketPath
+// if it is set, otherwise on the SPIFFE_ENDPOINT_SOCKET address.
+func newX509Source(ctx context.Context, socketPath string, timeout time.Duration) (*workloadapi.X509Source, error) {
+	var options []workloadapi.X509SourceOption
+	if socketPath != "" {
+		options = append(options, workloadapi.WithClientOptions(workloadapi.WithAddr("unix://"+socketPath)))
 	}
 
-	<-signalCtx.Done()
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	for interval := svidMinRetryInterval; ; interval = min(2*interval, svidMaxRetryInterval) {
+		tryStart := time.Now()
+		source, err := tryX509Source(waitCtx, socketPath, interval, options...)
+		if err == nil {
+			return source, nil
+		}
+		logrus.Warnf("waiting for SPIRE agent to provide the SVID for %s: %s", time.Since(start).Round(time.Second), err.Error())
+
+		select {
+		case <-waitCtx.Done():
+			return nil, errors.Wrapf(err, "SPIRE agent has not provided the SVID in %s", timeout)
+		case <-time.After(time.Until(tryStart.Add(interval))):
+		}
+	}
 }
 
-func exitOnErrCh(ctx context.Context, cancel context.CancelFunc, errCh <-chan error) {
-	// If we already have an error, log it and exit
-	select {
-	case err := <-errCh:
-		log.FromContext(ctx).Fatal(err)
-	default:
+// tryX509Source - waits up to timeout for the first SVID, fails right away if the socket doesn't exist yet
+func tryX509Source(ctx context.Context, socketPath string, timeout time.Duration,
+	options ...workloadapi.X509SourceOption) (*workloadapi.X509Source, error) {
+	if socketPath != "" {
+		if _, err := os.Stat(socketPath); err != nil {
+			return nil, errors.Wrap(err, "SPIFFE workload API socket is not available")
+		}
 	}
-	// Otherwise wait for an error in the background to log and cancel
-	go func(ctx context.Context, errCh <-chan error) {
-		err := <-errCh
-		log.FromContext(ctx).Error(err)
-		cancel()
-	}(ctx, errCh)
+	tryCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return workloadapi.NewX509Source(tryCtx, options...)
 }
 
-func notifyContext(ctx context.Context) (context.Context, context.CancelFunc) {
-	return signal.NotifyContext(
-		ctx,
+func notifyContext(ctx context.Context, stopOnHUP, stopOnQUIT bool) (context.Context, context.CancelFunc) {
+	signals := []os.Signal{
 		os.Interrupt,
 		// More Linux signals here
-		syscall.SIGHUP,
 		syscall.SIGTERM,
-		syscall.SIGQUIT,
-	)
+	}
+	if stopOnHUP {
+		signals = append(signals, syscall.SIGHUP)
+	}
+	if stopOnQUIT {
+		signals = append(signals, syscall.SIGQUIT)
+	}
+	return signal.NotifyContext(ctx, signals...)
 }
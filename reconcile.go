@@ -0,0 +1,105 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/connwatch"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// reconcileDown - the manual alternative to heal: requests again from scratch each connection which NSMgr doesn't
+// report as up, and requests the connections to the network services which have none, e.g. after a failed request,
+// except the ones closed for idleness.
+// Should be called from the same goroutine as the requests of all connections.
+func reconcileDown(ctx, signalCtx context.Context, config *Config, idSuffix string,
+	monitorClient networkservice.MonitorConnectionClient, nsmClient networkservice.NetworkServiceClient,
+	connections *connectionStore, settings *serviceSettings, rotations *rotations) {
+	states, err := monitorStates(signalCtx, config.Name, monitorClient, config.RequestTimeout)
+	if err != nil {
+		if signalCtx.Err() == nil {
+			log.FromContext(ctx).Errorf("reconcile: failed to get the state of the connections: %s", err.Error())
+		}
+		return
+	}
+
+	now := time.Now()
+	var down []string
+	for _, conn := range connections.list() {
+		if state, ok := states[conn.GetId()]; !ok || state != networkservice.State_UP {
+			down = append(down, conn.GetId())
+		}
+	}
+	// The network services whose connections are closed for idleness are not missing
+	missing := len(config.NetworkServices) - len(connections.list()) - connections.idleCount()
+	if len(down) == 0 && missing <= 0 {
+		log.FromContext(ctx).Debug("reconcile: all connections are up")
+		return
+	}
+	log.FromContext(ctx).Warnf("reconcile: %d connections are not up, %d are missing", len(down), missing)
+
+	for _, id := range down {
+		if err = requestConnectionAgain(ctx, signalCtx, config, id, monitorClient, nsmClient, connections,
+			settings, rotations); err != nil {
+			if signalCtx.Err() != nil {
+				log.FromContext(ctx).Info("reconcile: shutdown is requested while requesting connections again")
+				return
+			}
+			log.FromContext(ctx).WithField("id", id).Errorf("reconcile: failed to request connection again: %s", err.Error())
+		}
+	}
+	if missing > 0 {
+		reconcileConnections(ctx, signalCtx, config, idSuffix, monitorClient, nsmClient, connections, settings, rotations)
+	}
+	log.FromContext(ctx).WithField("duration", time.Since(now)).Info("reconcile: done")
+}
+
+// monitorStates - returns the state of each connection of the client known to NSMgr by its ID
+func monitorStates(ctx context.Context, name string, monitorClient networkservice.MonitorConnectionClient,
+	timeout time.Duration) (map[string]networkservice.State, error) {
+	monitorCtx, cancelMonitor := context.WithTimeout(ctx, timeout)
+	defer cancelMonitor()
+
+	stream, err := monitorClient.MonitorConnections(monitorCtx, &networkservice.MonitorScopeSelector{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to monitor connections")
+	}
+	conns, err := connwatch.InitialState(monitorCtx, stream, monitorSettle)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to receive connections")
+	}
+
+	states := make(map[string]networkservice.State)
+	for _, conn := range conns {
+		segments := conn.GetPath().GetPathSegments()
+		if len(segments) == 0 || !strings.HasPrefix(segments[0].GetId(), name+"-") {
+			continue
+		}
+		states[segments[0].GetId()] = conn.GetState()
+	}
+	return states, nil
+}
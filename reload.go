@@ -0,0 +1,166 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// readNetworkServices - reads the NSURLs of the network services from the file at path, one per line. The empty lines
+// and the lines starting with # are skipped.
+func readNetworkServices(path string) ([]url.URL, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open network services file %s", path)
+	}
+	defer func() { _ = file.Close() }()
+
+	var services []url.URL
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		u, err := url.Parse(line)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid network service %q in %s", line, path)
+		}
+		services = append(services, *u)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to read network services file %s", path)
+	}
+	return services, nil
+}
+
+// reloadNetworkServices - reads the network services file again and updates config with it, returns false if the
+// file is invalid, so the current network services are left intact
+func reloadNetworkServices(ctx context.Context, config *Config) bool {
+	log.FromContext(ctx).Infof("reloading network services from %s", config.NetworkServicesFile)
+	services, err := readNetworkServices(config.NetworkServicesFile)
+	if err != nil {
+		log.FromContext(ctx).Errorf("failed to reload network services: %s", err.Error())
+		return false
+	}
+	if err = expandInterfaceNames(services); err != nil {
+		log.FromContext(ctx).Errorf("failed to reload network services: %s", err.Error())
+		return false
+	}
+	reloaded := *config
+	reloaded.NetworkServices = services
+	if err = reloaded.validate(); err != nil {
+		log.FromContext(ctx).Errorf("failed to reload network services: %s", err.Error())
+		return false
+	}
+
+	config.NetworkServices = services
+	return true
+}
+
+// reconcileConnections - closes the connections to the network services which are not in config anymore and
+// requests the connections to the added ones and to the ones which have none, except the ones closed for idleness,
+// the connections to the unchanged network services are left intact.
+// Should be called from the same goroutine as the requests of all connections, the rotation of each closed
+// connection is stopped before it is closed, so its heal is stopped too.
+func reconcileConnections(ctx, signalCtx context.Context, config *Config, idSuffix string,
+	monitorClient networkservice.MonitorConnectionClient, nsmClient networkservice.NetworkServiceClient,
+	connections *connectionStore, settings *serviceSettings, rotations *rotations) {
+	current := connections.list()
+	kept := make(map[string]bool)
+	var added []int
+	for i := range config.NetworkServices {
+		service := config.NetworkServices[i].String()
+		found := false
+		for _, conn := range current {
+			if id := conn.GetId(); !kept[id] && connections.serviceOf(id) == service {
+				kept[id] = true
+				found = true
+				// The index of the network service may change on reload
+				connections.setIndex(id, i)
+				break
+			}
+		}
+		if !found && !connections.isIdle(i) {
+			added = append(added, i)
+		}
+	}
+	var removed []*networkservice.Connection
+	for _, conn := range current {
+		if !kept[conn.GetId()] {
+			removed = append(removed, conn)
+		}
+	}
+	log.FromContext(ctx).Infof("reconciling connections: %d unchanged, %d removed, %d added", len(kept), len(removed), len(added))
+
+	if len(removed) > 0 {
+		for _, conn := range removed {
+			rotations.stop(conn.GetId())
+		}
+		closeConnections(ctx, config, nsmClient, removed, config.GracefulShutdownTimeout)
+		for _, conn := range removed {
+			connections.delete(conn.GetId())
+			settings.delete(conn.GetId())
+		}
+	}
+
+	for _, index := range added {
+		service := config.NetworkServices[index].String()
+		id := freeConnectionID(config, idSuffix, index, connections)
+		resp, err := requestConnection(ctx, signalCtx, config, index, id, config.resumePolicy(false),
+			monitorClient, nsmClient, settings)
+		if err != nil {
+			settings.delete(id)
+			if signalCtx.Err() != nil {
+				log.FromContext(ctx).Infof("shutdown is requested while requesting connection to %s", service)
+				return
+			}
+			log.FromContext(ctx).Errorf("failed to request connection to %s: %s", service, err.Error())
+			continue
+		}
+		connections.add(index, service, resp)
+		if config.MaxConnectionLifetime > 0 {
+			rotations.start(signalCtx, resp.GetId(), config.MaxConnectionLifetime)
+		}
+	}
+}
+
+// freeConnectionID - returns the ID of the connection to the index-th network service if it is set by the
+// connectionId NSURL parameter or is not used by another connection, otherwise the first unused ID after the IDs of
+// all network services
+func freeConnectionID(config *Config, idSuffix string, index int, connections *connectionStore) string {
+	if id := config.NetworkServices[index].Query().Get(connectionIDParam); id != "" {
+		return id
+	}
+	id := connectionID(config.Name, idSuffix, index)
+	for i := len(config.NetworkServices); connections.load(id) != nil; i++ {
+		id = connectionID(config.Name, idSuffix, i)
+	}
+	return id
+}
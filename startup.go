@@ -0,0 +1,92 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// startupWatchdog - stops the client if the startup is not done in time, so a misconfigured pod crash-loops instead
+// of hanging
+type startupWatchdog struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	phase   string
+	expired error
+}
+
+// newStartupWatchdog - starts a watchdog calling stop if done is not called in timeout, disabled if timeout is 0. The
+// expiry is returned by err, so the client exits with it instead of a shutdown.
+func newStartupWatchdog(ctx context.Context, stop context.CancelFunc, timeout time.Duration) *startupWatchdog {
+	ctx, cancel := context.WithCancel(ctx)
+	w := &startupWatchdog{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	if timeout <= 0 {
+		return w
+	}
+	go func() {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+			w.mu.Lock()
+			w.expired = withExitCode(exitFailure, errors.Errorf("startup has not completed in %s, phase in progress: %s",
+				timeout, w.phase))
+			w.mu.Unlock()
+			stop()
+		}
+	}()
+	return w
+}
+
+// setPhase - sets the phase in progress
+func (w *startupWatchdog) setPhase(phase string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.phase = phase
+}
+
+// err - returns the error classified with the exit code if the startup has not completed in time, nil otherwise
+func (w *startupWatchdog) err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.expired
+}
+
+// done - stops the watchdog
+func (w *startupWatchdog) done() {
+	w.cancel()
+}
+
+// finished - returns a channel closed when the startup is done or ctx is done
+func (w *startupWatchdog) finished() <-chan struct{} {
+	return w.ctx.Done()
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/lasterrors"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/version"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/vppinit"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// lastErrorsSize - number of the last warnings and errors in the state dump
+const lastErrorsSize = 20
+
+// dumpState - writes the state of the client to StateDumpFile or to stderr if it is not set. Should be called from the
+// same goroutine as the requests of all connections.
+func dumpState(ctx context.Context, config *Config, starttime time.Time, connections []*networkservice.Connection,
+	vppConn api.Connection, lastErrors *lasterrors.Hook) {
+	var b strings.Builder
+	writeState(ctx, &b, config, starttime, connections, vppConn, lastErrors)
+
+	if config.StateDumpFile == "" {
+		_, _ = io.WriteString(os.Stderr, b.String())
+		return
+	}
+	if err := os.WriteFile(config.StateDumpFile, []byte(b.String()), 0o600); err != nil {
+		log.FromContext(ctx).Errorf("failed to write state dump: %s", err.Error())
+		return
+	}
+	log.FromContext(ctx).Infof("state is dumped to %s", config.StateDumpFile)
+}
+
+func writeState(ctx context.Context, w io.Writer, config *Config, starttime time.Time,
+	connections []*networkservice.Connection, vppConn api.Connection, lastErrors *lasterrors.Hook) {
+	now := time.Now()
+	_, _ = fmt.Fprintf(w, "=== state of %s at %s ===\n", config.Name, now.Format(time.RFC3339))
+	_, _ = fmt.Fprintf(w, "build: %s\n", version.Info())
+	_, _ = fmt.Fprintf(w, "started at: %s (%s ago)\n", starttime.Format(time.RFC3339), now.Sub(starttime).Round(time.Second))
+
+	_, _ = fmt.Fprint(w, "\n--- config ---\n")
+	// The fields are dumped in the order of declaration with the same redactions as the effective config is logged
+	effective := config.effective()
+	configType := reflect.TypeOf(*config)
+	for i := 0; i < configType.NumField(); i++ {
+		name := configType.Field(i).Name
+		_, _ = fmt.Fprintf(w, "%s: %v\n", name, effective[name])
+	}
+
+	_, _ = fmt.Fprintf(w, "\n--- connections (%d of %d network services) ---\n", len(connections), len(config.NetworkServices))
+	for _, conn := range connections {
+		_, _ = fmt.Fprintf(w, "%s: service %s, NSE %s, mechanism %s, interface %s, state %s, src %v, dst %v\n",
+			conn.GetId(), conn.GetNetworkService(), conn.GetNetworkServiceEndpointName(), conn.GetMechanism().GetType(),
+			conn.GetMechanism().GetParameters()[common.InterfaceNameKey], conn.GetState(),
+			conn.GetContext().GetIpContext().GetSrcIpAddrs(), conn.GetContext().GetIpContext().GetDstIpAddrs())
+	}
+
+	_, _ = fmt.Fprint(w, "\n--- VPP interfaces ---\n")
+	if interfaces, err := vppinit.Output(ctx, vppConn, "show interface address"); err == nil {
+		_, _ = fmt.Fprint(w, interfaces)
+	} else {
+		_, _ = fmt.Fprintf(w, "failed to get VPP interfaces: %s\n", err.Error())
+	}
+
+	_, _ = fmt.Fprintf(w, "\n--- last %d warnings and errors ---\n", lastErrorsSize)
+	for _, entry := range lastErrors.Entries() {
+		_, _ = fmt.Fprintf(w, "%s [%s] %s\n", entry.Time.Format(time.RFC3339), entry.Level, entry.Message)
+	}
+}
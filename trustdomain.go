@@ -0,0 +1,54 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// requestConnectionsForTrustDomain - closes each connection authenticated under the previous trust domain of the SVID
+// and requests it again from scratch, so NSMgr is dialed again with the SVID of trustDomain. The connection is left in
+// connections if the request fails, so the heal or the watchdog can still recover it. Should be called from the same
+// goroutine as the requests of all connections.
+func requestConnectionsForTrustDomain(ctx, signalCtx context.Context, config *Config, trustDomain spiffeid.TrustDomain,
+	monitorClient networkservice.MonitorConnectionClient, nsmClient networkservice.NetworkServiceClient,
+	connections *connectionStore, settings *serviceSettings, rotations *rotations) {
+	logger := log.FromContext(ctx).WithField("trustDomain", trustDomain.String())
+	logger.Warn("SVID trust domain has changed, requesting all connections again")
+
+	now := time.Now()
+	for _, conn := range connections.list() {
+		if err := requestConnectionAgain(ctx, signalCtx, config, conn.GetId(), monitorClient, nsmClient, connections,
+			settings, rotations); err != nil {
+			if signalCtx.Err() != nil {
+				logger.Info("shutdown is requested while requesting connections again for the new trust domain")
+				return
+			}
+			logger.WithField("id", conn.GetId()).Errorf("failed to request connection again for the new trust domain: %s", err.Error())
+		}
+	}
+	logger.WithField("duration", time.Since(now)).Info("all connections are requested again for the new trust domain")
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/clientconn"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testMonitorServer - MonitorConnectionServer sending the refresh requests for the monitored connections on demand
+type testMonitorServer struct {
+	mu       sync.Mutex
+	streams  map[string]chan *networkservice.ConnectionEvent
+	monitors int
+}
+
+func (s *testMonitorServer) MonitorConnections(selector *networkservice.MonitorScopeSelector, srv networkservice.MonitorConnection_MonitorConnectionsServer) error {
+	id := selector.GetPathSegments()[0].GetId()
+	events := make(chan *networkservice.ConnectionEvent, 1)
+
+	s.mu.Lock()
+	s.streams[id] = events
+	s.monitors++
+	s.mu.Unlock()
+
+	for {
+		select {
+		case event := <-events:
+			if err := srv.Send(event); err != nil {
+				return err
+			}
+		case <-srv.Context().Done():
+			return nil
+		}
+	}
+}
+
+// refresh - sends the refresh request for the connection with the id, returns false if it is not monitored
+func (s *testMonitorServer) refresh(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events, ok := s.streams[id]
+	if !ok {
+		return false
+	}
+	delete(s.streams, id)
+	events <- &networkservice.ConnectionEvent{
+		Type: networkservice.ConnectionEventType_UPDATE,
+		Connections: map[string]*networkservice.Connection{
+			id: {
+				Id:    id,
+				Path:  testPath(id),
+				State: networkservice.State_REFRESH_REQUESTED,
+			},
+		},
+	}
+	return true
+}
+
+func (s *testMonitorServer) monitored() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.monitors
+}
+
+func testPath(id string) *networkservice.Path {
+	return &networkservice.Path{
+		PathSegments: []*networkservice.PathSegment{{Id: id, Name: "nsc"}},
+	}
+}
+
+func (c *testNSMClient) requestCount(id string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	count := 0
+	for _, request := range c.requests {
+		if request.GetConnection().GetId() == id {
+			count++
+		}
+	}
+	return count
+}
+
+func testUpstreamRefresh(t *testing.T, config *Config) (nsmClient *testNSMClient, monitorServer *testMonitorServer) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	monitorServer = &testMonitorServer{streams: make(map[string]chan *networkservice.ConnectionEvent)}
+	networkservice.RegisterMonitorConnectionServer(server, monitorServer)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	cc, err := grpc.DialContext(ctx, "bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = cc.Close()
+	})
+
+	nsmClient = &testNSMClient{}
+	client := chain.NewNetworkServiceClient(
+		begin.NewClient(),
+		metadata.NewClient(),
+		clientconn.NewClient(cc),
+		newUpstreamRefreshClient(ctx, config),
+		nsmClient,
+	)
+	for _, id := range []string{"a", "b"} {
+		if _, err = client.Request(ctx, &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id:             id,
+				NetworkService: id,
+				Path:           testPath(id),
+			},
+			MechanismPreferences: []*networkservice.Mechanism{{Cls: "LOCAL", Type: kernel.MECHANISM}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return nsmClient, monitorServer
+}
+
+// waitRequests - waits for the connection with the id to be requested count times
+func waitRequests(t *testing.T, nsmClient *testNSMClient, id string, count int) {
+	for deadline := time.Now().Add(time.Second); nsmClient.requestCount(id) < count; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d requests of %s, got %d", count, id, nsmClient.requestCount(id))
+		}
+	}
+}
+
+// waitRefresh - waits for the connection with the id to be monitored and requests its refresh
+func waitRefresh(t *testing.T, monitorServer *testMonitorServer, id string) {
+	for deadline := time.Now().Add(time.Second); !monitorServer.refresh(id); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("%s is not monitored", id)
+		}
+	}
+}
+
+func TestUpstreamRefreshLocal(t *testing.T) {
+	config := &Config{EnableUpstreamRefresh: true, UpstreamRefreshLocal: true}
+	nsmClient, monitorServer := testUpstreamRefresh(t, config)
+
+	waitRefresh(t, monitorServer, "a")
+	waitRequests(t, nsmClient, "a", 2)
+	waitRequests(t, nsmClient, "b", 2)
+}
+
+func TestUpstreamRefreshNotLocal(t *testing.T) {
+	config := &Config{EnableUpstreamRefresh: true}
+	nsmClient, monitorServer := testUpstreamRefresh(t, config)
+
+	waitRefresh(t, monitorServer, "a")
+	waitRequests(t, nsmClient, "a", 2)
+	// Give a cascading refresh the time to happen
+	time.Sleep(100 * time.Millisecond)
+	if count := nsmClient.requestCount("b"); count != 1 {
+		t.Fatalf("expected b not to be refreshed, got %d requests", count)
+	}
+}
+
+func TestUpstreamRefreshDisabled(t *testing.T) {
+	config := &Config{}
+	nsmClient, monitorServer := testUpstreamRefresh(t, config)
+
+	// Give a monitoring the time to start
+	time.Sleep(100 * time.Millisecond)
+	if monitors := monitorServer.monitored(); monitors != 0 {
+		t.Fatalf("expected no connections to be monitored, got %d", monitors)
+	}
+	for _, id := range []string{"a", "b"} {
+		if count := nsmClient.requestCount(id); count != 1 {
+			t.Fatalf("expected %s not to be refreshed, got %d requests", id, count)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/vpphelper"
+
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/hugepages"
+	"github.com/networkservicemesh/cmd-nsc-vpp/internal/vppinit"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// vppDeathCloseTimeout - timeout to close the connections toward NSMgr after VPP has died. VPP calls of the chain fail
+// at that point, so the closes shouldn't take long.
+const vppDeathCloseTimeout = 5 * time.Second
+
+// vppConfigFile - VPP startup config file vpphelper writes the config generated from the template to, only if it does
+// not exist
+const vppConfigFile = "/etc/vpp/helper/vpp.conf"
+
+// vppProcess - running VPP and the connection to it
+type vppProcess struct {
+	// ctx - context of VPP, the chains using conn should be created with it
+	ctx    context.Context
+	cancel context.CancelFunc
+	// detach - stops the cancel of ctx with the context VPP is started with
+	detach func() bool
+	conn   api.Connection
+	errCh  <-chan error
+	// died - closed if VPP exits before stop is called
+	died chan struct{}
+	// started - when VPP was started
+	started time.Time
+}
+
+// startVpp - starts VPP, connects to it and runs the bootstrap commands
+func startVpp(ctx context.Context, config *Config, options ...vpphelper.Option) (*vppProcess, error) {
+	// VPP is stopped with the parent context unless it is kept
+	parentCtx := ctx
+	ctx, cancel := context.WithCancel(context.WithoutCancel(parentCtx))
+	detach := context.AfterFunc(parentCtx, cancel)
+	conn, errCh := vpphelper.StartAndDialContext(ctx, options...)
+	select {
+	case err := <-errCh:
+		cancel()
+		return nil, errors.Wrap(err, "VPP has failed to start")
+	default:
+	}
+
+	p := &vppProcess{
+		ctx:     ctx,
+		cancel:  cancel,
+		detach:  detach,
+		conn:    conn,
+		errCh:   errCh,
+		died:    make(chan struct{}),
+		started: time.Now(),
+	}
+	go func() {
+		err := <-errCh
+		if ctx.Err() != nil {
+			return
+		}
+		log.FromContext(ctx).Errorf("VPP has died: %v", err)
+		close(p.died)
+	}()
+
+	if err := vppinit.RunCommands(ctx, conn, config.VppBootstrapCommands...); err != nil {
+		p.stop()
+		return nil, errors.Wrap(err, "error running VPP bootstrap commands")
+	}
+	if config.VppCPUList != "" || config.VppWorkers > 0 {
+		threads, err := vppinit.Output(ctx, conn, "show threads")
+		if err != nil {
+			p.stop()
+			return nil, errors.Wrap(err, "error getting VPP threads")
+		}
+		log.FromContext(ctx).Infof("VPP threads:\n%s", threads)
+	}
+	return p, nil
+}
+
+// checkVppConfigFile - fails if the VPP startup config file exists before VPP is started for the first time, VPP would
+// silently use it instead of the config generated from the template, e.g. without the CPU pinning or the hugepages
+// settings
+func checkVppConfigFile() error {
+	_, err := os.Stat(vppConfigFile)
+	if err == nil {
+		return errors.Errorf("VPP startup config %s exists and would be used instead of the generated one: remove it, "+
+			"or set %s to use a custom config template", vppConfigFile, vppConfigPathEnv)
+	}
+	if !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to check VPP startup config %s", vppConfigFile)
+	}
+	return nil
+}
+
+// vppRestarter - restarts VPP after it dies with backoff, up to VppMaxRestarts times
+type vppRestarter struct {
+	config   *Config
+	sockets  []string
+	restarts int
+	interval time.Duration
+}
+
+// vppSocketRegexp - matches the unix sockets VPP listens on in its startup config template
+var vppSocketRegexp = regexp.MustCompile(`(?m)^\s*(?:socket-name|cli-listen)\s+(.+?)\s*$`)
+
+func newVppRestarter(config *Config, template string) *vppRestarter {
+	r := &vppRestarter{
+		config:   config,
+		interval: config.VppRestartInterval,
+	}
+	for _, match := range vppSocketRegexp.FindAllStringSubmatch(template, -1) {
+		// The sockets are in the root dir of vpphelper, which is the filesystem root
+		if socket := strings.ReplaceAll(match[1], "{{ .RootDir }}", ""); filepath.IsAbs(socket) {
+			r.sockets = append(r.sockets, socket)
+		}
+	}
+	return r
+}
+
+// restart - removes the sockets left by the dead VPP and starts it again after the backoff. A failed start counts as a
+// restart and is tried again. Fails once the restarts are used up or signalCtx is done.
+func (r *vppRestarter) restart(ctx, signalCtx context.Context, dead *vppProcess, options ...vpphelper.Option) (*vppProcess, error) {
+	logger := log.FromContext(ctx)
+	// VPP which ran long enough isn't failing in a loop, so the backoff starts over
+	if time.Since(dead.started) >= r.config.VppRestartMaxInterval {
+		r.interval = r.config.VppRestartInterval
+	}
+	for {
+		if r.config.VppMaxRestarts > 0 && r.restarts >= r.config.VppMaxRestarts {
+			return nil, errors.Errorf("VPP is restarted %d times already", r.restarts)
+		}
+		r.restarts++
+		logger.Infof("restarting VPP in %v: restart %d", r.interval, r.restarts)
+		select {
+		case <-signalCtx.Done():
+			return nil, errors.Wrap(signalCtx.Err(), "VPP restart is cancelled")
+		case <-time.After(r.interval):
+		}
+		r.interval = min(2*r.interval, r.config.VppRestartMaxInterval)
+
+		r.removeSockets(ctx)
+		now := time.Now()
+		vpp, err := startVpp(ctx, r.config, options...)
+		if err == nil {
+			logger.WithField("duration", time.Since(now)).Infof("VPP is restarted: restart %d", r.restarts)
+			return vpp, nil
+		}
+		logger.Errorf("failed to restart VPP: %s", err.Error())
+	}
+}
+
+// removeSockets - removes the sockets of the dead VPP, so nothing dials them before the new VPP listens
+func (r *vppRestarter) removeSockets(ctx context.Context) {
+	for _, socket := range r.sockets {
+		if err := os.Remove(socket); err == nil {
+			log.FromContext(ctx).Debugf("removed socket %s of the dead VPP", socket)
+		} else if !os.IsNotExist(err) {
+			log.FromContext(ctx).Warnf("failed to remove socket %s of the dead VPP: %s", socket, err.Error())
+		}
+	}
+}
+
+// pinVppThreads - returns the VPP startup config template with the main thread pinned to the first of cpus and a
+// worker pinned to each of the others, or with the number of workers pinned by VPP. The settings are added to the cpu
+// section of the template, the section is added if the template has none.
+func pinVppThreads(template string, cpus []int, workers int) string {
+	var settings []string
+	if len(cpus) > 0 {
+		settings = append(settings, fmt.Sprintf("main-core %d", cpus[0]))
+	}
+	if len(cpus) > 1 {
+		var workerCPUs []string
+		for _, cpu := range cpus[1:] {
+			workerCPUs = append(workerCPUs, strconv.Itoa(cpu))
+		}
+		settings = append(settings, "corelist-workers "+strings.Join(workerCPUs, ","))
+	}
+	if workers > 0 {
+		settings = append(settings, fmt.Sprintf("workers %d", workers))
+	}
+	return addToSection(template, "cpu", settings...)
+}
+
+// useHugepages - logs the hugepages of the node and returns the VPP startup config template for them: as it is if
+// there are free hugepages, with the VPP memory on 4K pages if there are none and AllowNoHugepages is set, otherwise
+// fails with the steps to fix it
+func useHugepages(ctx context.Context, config *Config, template string) (string, error) {
+	info, err := hugepages.Read(hugepages.MeminfoPath)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to detect hugepages")
+	}
+	log.FromContext(ctx).Infof("hugepages: %d free of %d, page size %d kB", info.Free, info.Total, info.PageSize/1024)
+	if info.Free > 0 {
+		return template, nil
+	}
+	if !config.AllowNoHugepages {
+		return "", errors.Errorf("no free hugepages on the node, VPP fails to allocate its buffers or runs slowly without "+
+			"them: reserve hugepages on the node, e.g. with the vm.nr_hugepages sysctl, and request hugepages-%s in the "+
+			"pod resources, or set %s=true to run VPP on 4K pages", hugepageResourceSize(info.PageSize), allowNoHugepagesEnv)
+	}
+	log.FromContext(ctx).Warn("hugepages: none is free, VPP memory is set to 4K pages, the performance is lower")
+	template = addToSection(template, "memory", "main-heap-page-size 4k")
+	return addToSection(template, "buffers", "page-size 4k"), nil
+}
+
+// hugepageResourceSize - returns the page size as in the name of the Kubernetes hugepages resource, e.g. 2Mi
+func hugepageResourceSize(pageSize uint64) string {
+	const gi = 1 << 30
+	if pageSize >= gi && pageSize%gi == 0 {
+		return fmt.Sprintf("%dGi", pageSize/gi)
+	}
+	return fmt.Sprintf("%dMi", pageSize>>20)
+}
+
+// addToSection - returns the VPP startup config template with settings added to the start of its section, the
+// section is added if the template has none
+func addToSection(template, section string, settings ...string) string {
+	if len(settings) == 0 {
+		return template
+	}
+	lines := "\n  " + strings.Join(settings, "\n  ")
+	start := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(section) + `\s*\{`)
+	if loc := start.FindStringIndex(template); loc != nil {
+		return template[:loc[1]] + lines + template[loc[1]:]
+	}
+	return template + "\n" + section + " {" + lines + "\n}\n"
+}
+
+// dialVpp - connects to VPP started by another process at socket, it is expected to run the bootstrap commands. VPP
+// isn't stopped by stop and its death isn't detected.
+func dialVpp(ctx context.Context, socket string) *vppProcess {
+	ctx, cancel := context.WithCancel(ctx)
+	errCh := make(chan error)
+	go func() {
+		<-ctx.Done()
+		close(errCh)
+	}()
+	return &vppProcess{
+		ctx:    ctx,
+		cancel: cancel,
+		conn:   vpphelper.DialContext(ctx, socket),
+		errCh:  errCh,
+		died:   make(chan struct{}),
+	}
+}
+
+// keep - leaves VPP running after the context it is started with is done, e.g. for the client in run mode
+func (p *vppProcess) keep() {
+	if p.detach != nil {
+		p.detach()
+	}
+}
+
+// stop - stops VPP and waits for it to exit
+func (p *vppProcess) stop() {
+	p.cancel()
+	<-p.errCh
+}
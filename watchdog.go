@@ -0,0 +1,55 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// recoverConnection - closes the connection with the id reported by the watchdog and requests it again from scratch.
+// The connection is left in connections if the request fails, so it is recovered again on the next report. Should be
+// called from the same goroutine as the requests of all connections.
+func recoverConnection(ctx, signalCtx context.Context, config *Config, id string,
+	monitorClient networkservice.MonitorConnectionClient, nsmClient networkservice.NetworkServiceClient,
+	connections *connectionStore, settings *serviceSettings, rotations *rotations) {
+	conn := connections.load(id)
+	if conn == nil {
+		return
+	}
+	logger := log.FromContext(ctx).WithField("id", id)
+	logger.Warnf("watchdog: connection to %s has been down for more than %s, requesting it again",
+		conn.GetNetworkService(), config.WatchdogThreshold)
+
+	now := time.Now()
+	if err := requestConnectionAgain(ctx, signalCtx, config, id, monitorClient, nsmClient, connections, settings,
+		rotations); err != nil {
+		if signalCtx.Err() != nil {
+			logger.Info("watchdog: shutdown is requested while recovering connection")
+			return
+		}
+		logger.Errorf("watchdog: failed to recover connection: %s", err.Error())
+		return
+	}
+	logger.WithField("duration", time.Since(now)).Info("watchdog: connection is recovered")
+}